@@ -280,6 +280,28 @@ type Float64Level3 interface {
 	Dtrsm(s Side, ul Uplo, tA Transpose, d Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int)
 }
 
+// Float64Accurate implements extended-accuracy double precision routines
+// that are not part of the BLAS standard. Their results are as accurate as if
+// they had been computed in twice the working precision and then rounded.
+type Float64Accurate interface {
+	DdotCompensated(n int, x []float64, incX int, y []float64, incY int) float64
+	DdotBound(n int, x []float64, incX int, y []float64, incY int) (dot, bound float64)
+	DsdotCompensated(n int, x []float32, incX int, y []float32, incY int) float64
+	DasumCompensated(n int, x []float64, incX int) float64
+	DgemvExtra(tA Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int)
+	DsymvExtra(ul Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int)
+}
+
+// Float32Mixed implements mixed precision routines that read single
+// precision matrices and accumulate in double precision. They are not part
+// of the BLAS standard.
+type Float32Mixed interface {
+	Dsgemv(tA Transpose, m, n int, alpha float64, a []float32, lda int, x []float64, incX int, beta float64, y []float64, incY int)
+	Sdsgemv(tA Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int)
+	Dsgemm(tA, tB Transpose, m, n, k int, alpha float64, a []float32, lda int, b []float32, ldb int, beta float64, c []float64, ldc int)
+	Sdsgemm(tA, tB Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)
+}
+
 // Complex64 implements the single precision complex BLAS routines.
 type Complex64 interface {
 	Complex64Level1
@@ -9,7 +9,10 @@
 package blas32
 
 import (
+	"fmt"
+
 	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
 	"github.com/gonum/blas/native"
 )
 
@@ -388,6 +391,117 @@ func Gemm(tA, tB blas.Transpose, alpha float32, a, b General, beta float32, c Ge
 	blas32.Sgemm(tA, tB, m, n, k, alpha, a.Data, a.Stride, b.Data, b.Stride, beta, c.Data, c.Stride)
 }
 
+// GemmMixed computes
+//  C = alpha * A * B + beta * C,
+// where A, B, and C are dense matrices, and alpha and beta are scalars.
+// tA and tB specify whether A or B are transposed. The products are
+// accumulated in double precision and only the elements of C are rounded to
+// single precision.
+//
+// If the current implementation does not implement blas.Float32Mixed,
+// native.Implementation is used.
+func GemmMixed(tA, tB blas.Transpose, alpha float32, a, b General, beta float32, c General) {
+	m, n, k := gemmDims(tA, tB, a, b)
+	mixed().Sdsgemm(tA, tB, m, n, k, alpha, a.Data, a.Stride, b.Data, b.Stride, beta, c.Data, c.Stride)
+}
+
+// GemmMixed64 computes
+//  C = alpha * A * B + beta * C,
+// where A and B are dense single precision matrices, C is a dense double
+// precision matrix, and alpha and beta are scalars. tA and tB specify whether
+// A or B are transposed. The products are accumulated in double precision.
+//
+// If the current implementation does not implement blas.Float32Mixed,
+// native.Implementation is used.
+func GemmMixed64(tA, tB blas.Transpose, alpha float64, a, b General, beta float64, c blas64.General) {
+	m, n, k := gemmDims(tA, tB, a, b)
+	mixed().Dsgemm(tA, tB, m, n, k, alpha, a.Data, a.Stride, b.Data, b.Stride, beta, c.Data, c.Stride)
+}
+
+// gemmDims returns the dimensions of the product of op(A) and op(B).
+func gemmDims(tA, tB blas.Transpose, a, b General) (m, n, k int) {
+	if tA == blas.NoTrans {
+		m, k = a.Rows, a.Cols
+	} else {
+		m, k = a.Cols, a.Rows
+	}
+	if tB == blas.NoTrans {
+		n = b.Cols
+	} else {
+		n = b.Rows
+	}
+	return m, n, k
+}
+
+// mixed returns the current implementation if it implements
+// blas.Float32Mixed, and native.Implementation otherwise.
+func mixed() blas.Float32Mixed {
+	if impl, ok := blas32.(blas.Float32Mixed); ok {
+		return impl
+	}
+	return native.Implementation{}
+}
+
+// GemmBatched computes
+//  C[i] = alpha * A[i] * B[i] + beta * C[i],
+// for each i, where the A[i], B[i], and C[i] are dense matrices, and alpha and
+// beta are scalars. tA and tB specify whether the A[i] or B[i] are transposed.
+// The A[i] must all have the size and stride of a[0], and likewise for the
+// B[i] and C[i].
+//
+// If the current implementation provides SgemmBatched, as native.Implementation
+// does, the whole batch is passed to it. Otherwise Gemm is called for each i.
+func GemmBatched(tA, tB blas.Transpose, alpha float32, a, b []General, beta float32, c []General) {
+	if len(a) != len(c) || len(b) != len(c) {
+		panic(badBatchLen)
+	}
+	if len(c) == 0 {
+		return
+	}
+	for i := range c {
+		if !sameShape(a[i], a[0]) || !sameShape(b[i], b[0]) || !sameShape(c[i], c[0]) {
+			panic(batchEntry(badBatchShape, i))
+		}
+	}
+	batcher, ok := blas32.(gemmBatcher)
+	if !ok {
+		for i := range c {
+			Gemm(tA, tB, alpha, a[i], b[i], beta, c[i])
+		}
+		return
+	}
+	m, n, k := gemmDims(tA, tB, a[0], b[0])
+	ad := make([][]float32, len(a))
+	bd := make([][]float32, len(b))
+	cd := make([][]float32, len(c))
+	for i := range c {
+		ad[i] = a[i].Data
+		bd[i] = b[i].Data
+		cd[i] = c[i].Data
+	}
+	batcher.SgemmBatched(tA, tB, m, n, k, alpha, ad, a[0].Stride, bd, b[0].Stride, beta, cd, c[0].Stride)
+}
+
+// gemmBatcher is implemented by BLAS implementations that provide batched
+// matrix multiplication.
+type gemmBatcher interface {
+	SgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int, beta float32, c [][]float32, ldc int)
+}
+
+const (
+	badBatchLen   = "blas32: mismatched batch lengths"
+	badBatchShape = "blas32: mismatched matrix shape"
+)
+
+// batchEntry is as in package blas64.
+func batchEntry(msg string, i int) string {
+	return fmt.Sprintf("%s in batch entry %d", msg, i)
+}
+
+func sameShape(a, b General) bool {
+	return a.Rows == b.Rows && a.Cols == b.Cols && a.Stride == b.Stride
+}
+
 // Symm performs
 //  C = alpha * A * B + beta * C, if s == blas.Left,
 //  C = alpha * B * A + beta * C, if s == blas.Right,
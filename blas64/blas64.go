@@ -9,6 +9,8 @@
 package blas64
 
 import (
+	"fmt"
+
 	"github.com/gonum/blas"
 	"github.com/gonum/blas/native"
 )
@@ -131,6 +133,45 @@ func Asum(n int, x Vector) float64 {
 	return blas64.Dasum(n, x.Data, x.Inc)
 }
 
+// DotCompensated computes the dot product of the two vectors:
+//  \sum_i x[i]*y[i],
+// as accurately as if it were computed in twice the working precision and
+// then rounded.
+//
+// If the current implementation does not implement blas.Float64Accurate,
+// native.Implementation is used.
+func DotCompensated(n int, x, y Vector) float64 {
+	return accurate().DdotCompensated(n, x.Data, x.Inc, y.Data, y.Inc)
+}
+
+// DotBound computes the dot product of the two vectors as DotCompensated
+// does, and returns it together with an upper bound on its absolute error.
+func DotBound(n int, x, y Vector) (dot, bound float64) {
+	return accurate().DdotBound(n, x.Data, x.Inc, y.Data, y.Inc)
+}
+
+// AsumCompensated computes the sum of the absolute values of the elements of x:
+//  \sum_i |x[i]|,
+// as accurately as if it were computed in twice the working precision and
+// then rounded.
+//
+// AsumCompensated will panic if the vector increment is negative.
+func AsumCompensated(n int, x Vector) float64 {
+	if x.Inc < 0 {
+		panic(negInc)
+	}
+	return accurate().DasumCompensated(n, x.Data, x.Inc)
+}
+
+// accurate returns the current implementation if it implements
+// blas.Float64Accurate, and native.Implementation otherwise.
+func accurate() blas.Float64Accurate {
+	if impl, ok := blas64.(blas.Float64Accurate); ok {
+		return impl
+	}
+	return native.Implementation{}
+}
+
 // Iamax returns the index of an element of x with the largest absolute value.
 // If there are multiple such indices the earliest is returned.
 // Iamax returns -1 if n == 0.
@@ -219,6 +260,67 @@ func Gemv(t blas.Transpose, alpha float64, a General, x Vector, beta float64, y
 	blas64.Dgemv(t, a.Rows, a.Cols, alpha, a.Data, a.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
 }
 
+// GemvExtra computes
+//  y = alpha * A * x + beta * y,   if t == blas.NoTrans,
+//  y = alpha * A^T * x + beta * y, if t == blas.Trans or blas.ConjTrans,
+// where A is an m×n dense matrix, x and y are vectors, and alpha and beta are scalars.
+// Each element of y is computed as accurately as if the expression were
+// evaluated in twice the working precision and then rounded.
+//
+// If the current implementation does not implement blas.Float64Accurate,
+// native.Implementation is used.
+func GemvExtra(t blas.Transpose, alpha float64, a General, x Vector, beta float64, y Vector) {
+	accurate().DgemvExtra(t, a.Rows, a.Cols, alpha, a.Data, a.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
+}
+
+// GemvBatched computes
+//  y[i] = alpha * A[i] * x[i] + beta * y[i],   if t == blas.NoTrans,
+//  y[i] = alpha * A[i]^T * x[i] + beta * y[i], if t == blas.Trans or blas.ConjTrans,
+// for each i, where the A[i] are m×n dense matrices, the x[i] and y[i] are
+// vectors, and alpha and beta are scalars. The A[i] must all have the size and
+// stride of a[0], and the x[i] and y[i] the increments of x[0] and y[0].
+//
+// If the current implementation provides DgemvBatched, as native.Implementation
+// does, the whole batch is passed to it. Otherwise Gemv is called for each i.
+func GemvBatched(t blas.Transpose, alpha float64, a []General, x []Vector, beta float64, y []Vector) {
+	if len(a) != len(y) || len(x) != len(y) {
+		panic(badBatchLen)
+	}
+	if len(y) == 0 {
+		return
+	}
+	for i := range y {
+		if !sameShape(a[i], a[0]) {
+			panic(batchEntry(badBatchShape, i))
+		}
+		if x[i].Inc != x[0].Inc || y[i].Inc != y[0].Inc {
+			panic(batchEntry(badBatchInc, i))
+		}
+	}
+	batcher, ok := blas64.(gemvBatcher)
+	if !ok {
+		for i := range y {
+			Gemv(t, alpha, a[i], x[i], beta, y[i])
+		}
+		return
+	}
+	ad := make([][]float64, len(a))
+	xd := make([][]float64, len(x))
+	yd := make([][]float64, len(y))
+	for i := range y {
+		ad[i] = a[i].Data
+		xd[i] = x[i].Data
+		yd[i] = y[i].Data
+	}
+	batcher.DgemvBatched(t, a[0].Rows, a[0].Cols, alpha, ad, a[0].Stride, xd, x[0].Inc, beta, yd, y[0].Inc)
+}
+
+// gemvBatcher is implemented by BLAS implementations that provide batched
+// matrix-vector multiplication.
+type gemvBatcher interface {
+	DgemvBatched(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int)
+}
+
 // Gbmv computes
 //  y = alpha * A * x + beta * y,   if t == blas.NoTrans,
 //  y = alpha * A^T * x + beta * y, if t == blas.Trans or blas.ConjTrans,
@@ -302,6 +404,18 @@ func Symv(alpha float64, a Symmetric, x Vector, beta float64, y Vector) {
 	blas64.Dsymv(a.Uplo, a.N, alpha, a.Data, a.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
 }
 
+// SymvExtra computes
+//    y = alpha * A * x + beta * y,
+// where A is an n×n symmetric matrix, x and y are vectors, and alpha and
+// beta are scalars. Each element of y is computed as accurately as if the
+// expression were evaluated in twice the working precision and then rounded.
+//
+// If the current implementation does not implement blas.Float64Accurate,
+// native.Implementation is used.
+func SymvExtra(alpha float64, a Symmetric, x Vector, beta float64, y Vector) {
+	accurate().DsymvExtra(a.Uplo, a.N, alpha, a.Data, a.Stride, x.Data, x.Inc, beta, y.Data, y.Inc)
+}
+
 // Sbmv performs
 //  y = alpha * A * x + beta * y,
 // where A is an n×n symmetric band matrix, x and y are vectors, and alpha
@@ -376,6 +490,82 @@ func Gemm(tA, tB blas.Transpose, alpha float64, a, b General, beta float64, c Ge
 	blas64.Dgemm(tA, tB, m, n, k, alpha, a.Data, a.Stride, b.Data, b.Stride, beta, c.Data, c.Stride)
 }
 
+// GemmBatched computes
+//  C[i] = alpha * A[i] * B[i] + beta * C[i],
+// for each i, where the A[i], B[i], and C[i] are dense matrices, and alpha and
+// beta are scalars. tA and tB specify whether the A[i] or B[i] are transposed.
+// The A[i] must all have the size and stride of a[0], and likewise for the
+// B[i] and C[i].
+//
+// If the current implementation provides DgemmBatched, as native.Implementation
+// does, the whole batch is passed to it. Otherwise Gemm is called for each i.
+func GemmBatched(tA, tB blas.Transpose, alpha float64, a, b []General, beta float64, c []General) {
+	if len(a) != len(c) || len(b) != len(c) {
+		panic(badBatchLen)
+	}
+	if len(c) == 0 {
+		return
+	}
+	for i := range c {
+		if !sameShape(a[i], a[0]) || !sameShape(b[i], b[0]) || !sameShape(c[i], c[0]) {
+			panic(batchEntry(badBatchShape, i))
+		}
+	}
+	batcher, ok := blas64.(gemmBatcher)
+	if !ok {
+		for i := range c {
+			Gemm(tA, tB, alpha, a[i], b[i], beta, c[i])
+		}
+		return
+	}
+	m, n, k := gemmDims(tA, tB, a[0], b[0])
+	ad := make([][]float64, len(a))
+	bd := make([][]float64, len(b))
+	cd := make([][]float64, len(c))
+	for i := range c {
+		ad[i] = a[i].Data
+		bd[i] = b[i].Data
+		cd[i] = c[i].Data
+	}
+	batcher.DgemmBatched(tA, tB, m, n, k, alpha, ad, a[0].Stride, bd, b[0].Stride, beta, cd, c[0].Stride)
+}
+
+// gemmDims returns the dimensions of the product of op(A) and op(B).
+func gemmDims(tA, tB blas.Transpose, a, b General) (m, n, k int) {
+	if tA == blas.NoTrans {
+		m, k = a.Rows, a.Cols
+	} else {
+		m, k = a.Cols, a.Rows
+	}
+	if tB == blas.NoTrans {
+		n = b.Cols
+	} else {
+		n = b.Rows
+	}
+	return m, n, k
+}
+
+// gemmBatcher is implemented by BLAS implementations that provide batched
+// matrix multiplication.
+type gemmBatcher interface {
+	DgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int, beta float64, c [][]float64, ldc int)
+}
+
+const (
+	badBatchLen   = "blas64: mismatched batch lengths"
+	badBatchShape = "blas64: mismatched matrix shape"
+	badBatchInc   = "blas64: mismatched vector increment"
+)
+
+// batchEntry adds the index i of the failing batch entry to msg.
+func batchEntry(msg string, i int) string {
+	return fmt.Sprintf("%s in batch entry %d", msg, i)
+}
+
+func sameShape(a, b General) bool {
+	return a.Rows == b.Rows && a.Cols == b.Cols && a.Stride == b.Stride
+}
+
 // Symm performs
 //  C = alpha * A * B + beta * C, if s == blas.Left,
 //  C = alpha * B * A + beta * C, if s == blas.Right,
@@ -447,3 +637,55 @@ func Trmm(s blas.Side, tA blas.Transpose, alpha float64, a Triangular, b General
 func Trsm(s blas.Side, tA blas.Transpose, alpha float64, a Triangular, b General) {
 	blas64.Dtrsm(s, a.Uplo, tA, a.Diag, b.Rows, b.Cols, alpha, a.Data, a.Stride, b.Data, b.Stride)
 }
+
+// TrsmBatched solves
+//  A[i] * X[i] = alpha * B[i],   if tA == blas.NoTrans and s == blas.Left,
+//  A[i]^T * X[i] = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and s == blas.Left,
+//  X[i] * A[i] = alpha * B[i],   if tA == blas.NoTrans and s == blas.Right,
+//  X[i] * A[i]^T = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and s == blas.Right,
+// for each i, where the A[i] are n×n or m×m triangular matrices, the X[i] and
+// B[i] are m×n matrices, and alpha is a scalar. The A[i] must all have the
+// size, stride, triangle and diagonal kind of a[0], and the B[i] the size and
+// stride of b[0].
+//
+// At entry to the function, X[i] contains the values of B[i], and the result
+// is stored in-place into X[i].
+//
+// If the current implementation provides DtrsmBatched, as native.Implementation
+// does, the whole batch is passed to it. Otherwise Trsm is called for each i.
+//
+// No check is made that the A[i] are invertible.
+func TrsmBatched(s blas.Side, tA blas.Transpose, alpha float64, a []Triangular, b []General) {
+	if len(a) != len(b) {
+		panic(badBatchLen)
+	}
+	if len(b) == 0 {
+		return
+	}
+	for i := range b {
+		ai := a[i]
+		if ai.N != a[0].N || ai.Stride != a[0].Stride || ai.Uplo != a[0].Uplo || ai.Diag != a[0].Diag || !sameShape(b[i], b[0]) {
+			panic(batchEntry(badBatchShape, i))
+		}
+	}
+	batcher, ok := blas64.(trsmBatcher)
+	if !ok {
+		for i := range b {
+			Trsm(s, tA, alpha, a[i], b[i])
+		}
+		return
+	}
+	ad := make([][]float64, len(a))
+	bd := make([][]float64, len(b))
+	for i := range b {
+		ad[i] = a[i].Data
+		bd[i] = b[i].Data
+	}
+	batcher.DtrsmBatched(s, a[0].Uplo, tA, a[0].Diag, b[0].Rows, b[0].Cols, alpha, ad, a[0].Stride, bd, b[0].Stride)
+}
+
+// trsmBatcher is implemented by BLAS implementations that provide batched
+// triangular solves.
+type trsmBatcher interface {
+	DtrsmBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a [][]float64, lda int, b [][]float64, ldb int)
+}
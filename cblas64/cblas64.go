@@ -10,15 +10,13 @@ package cblas64
 
 import (
 	"github.com/gonum/blas"
-	"github.com/gonum/blas/cgo"
+	"github.com/gonum/blas/native"
 )
 
-// TODO(kortschak): Change this and the comment below to native.Implementation
-// when blas/native covers the complex BLAS API.
-var cblas64 blas.Complex64 = cgo.Implementation{}
+var cblas64 blas.Complex64 = native.Implementation{}
 
 // Use sets the BLAS complex64 implementation to be used by subsequent BLAS calls.
-// The default implementation is cgo.Implementation.
+// The default implementation is native.Implementation.
 func Use(b blas.Complex64) {
 	cblas64 = b
 }
@@ -32,7 +32,7 @@ const (
 
 	prefix = "cblas_"
 
-	warning = "Float32 implementations are autogenerated and not directly tested."
+	warning = "Complex64 implementations are autogenerated and not directly tested."
 )
 
 const (
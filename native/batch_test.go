@@ -0,0 +1,379 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+var gemmBatchTests = []struct {
+	m, n, k, batch int
+}{
+	{0, 3, 3, 2},
+	{3, 4, 0, 5},
+	{1, 1, 1, 1},
+	{8, 8, 8, 1000},
+	{5, 7, 3, 37},
+	{40, 30, 50, 20},
+}
+
+func TestDgemmBatched(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range gemmBatchTests {
+		m, n, k, batch := test.m, test.n, test.k, test.batch
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float64{0, 0.5} {
+					prefix := fmt.Sprintf("m=%v,n=%v,k=%v,batch=%v,tA=%v,tB=%v,beta=%v", m, n, k, batch, tA, tB, beta)
+					ar, ac := m, k
+					if tA == blas.Trans {
+						ar, ac = k, m
+					}
+					br, bc := k, n
+					if tB == blas.Trans {
+						br, bc = n, k
+					}
+					lda, ldb, ldc := ac+1, bc+2, n+3
+					strideA := max(ar*lda, 1)
+					strideB := max(br*ldb, 1)
+					strideC := max(m*ldc, 1)
+					a := randmat(batch, strideA, strideA).data
+					b := randmat(batch, strideB, strideB).data
+					c := randmat(batch, strideC, strideC).data
+
+					want := make([]float64, len(c))
+					copy(want, c)
+					for i := 0; i < batch; i++ {
+						Implementation{Serial: true}.Dgemm(tA, tB, m, n, k, 1.5, a[i*strideA:], lda, b[i*strideB:], ldb, beta, want[i*strideC:], ldc)
+					}
+
+					for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+						got := make([]float64, len(c))
+						copy(got, c)
+						impl.DgemmStridedBatched(tA, tB, m, n, k, 1.5, a, lda, strideA, b, ldb, strideB, beta, got, ldc, strideC, batch)
+						if !closeFloat64s(got, want, 1e-12) {
+							t.Errorf("%v, %+v: unexpected strided result", prefix, impl)
+						}
+
+						copy(got, c)
+						as := make([][]float64, batch)
+						bs := make([][]float64, batch)
+						cs := make([][]float64, batch)
+						for i := range cs {
+							as[i] = a[i*strideA : (i+1)*strideA]
+							bs[i] = b[i*strideB : (i+1)*strideB]
+							cs[i] = got[i*strideC : (i+1)*strideC]
+						}
+						impl.DgemmBatched(tA, tB, m, n, k, 1.5, as, lda, bs, ldb, beta, cs, ldc)
+						if !closeFloat64s(got, want, 1e-12) {
+							t.Errorf("%v, %+v: unexpected pointer-array result", prefix, impl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDgemmStridedBatchedBroadcast(t *testing.T) {
+	const m, n, k, batch = 4, 5, 6, 50
+	a := randmat(m, k, k).data
+	b := randmat(batch*k, n, n).data
+	c := make([]float64, batch*m*n)
+	Implementation{}.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, 0, b, n, k*n, 0, c, n, m*n, batch)
+	for i := 0; i < batch; i++ {
+		want := make([]float64, m*n)
+		Implementation{}.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, b[i*k*n:], n, 0, want, n)
+		if !closeFloat64s(c[i*m*n:(i+1)*m*n], want, 1e-14) {
+			t.Errorf("product %d: unexpected result with shared A", i)
+		}
+	}
+}
+
+func TestDgemmBatchedPanics(t *testing.T) {
+	a := make([]float64, 100)
+	for _, test := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{
+			name: "length",
+			fn: func() {
+				Implementation{}.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, [][]float64{a, a}, 2, [][]float64{a}, 2, 0, [][]float64{a, a}, 2)
+			},
+			want: badBatchLen,
+		},
+		{
+			name: "batch",
+			fn: func() {
+				Implementation{}.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 4, a, 2, 4, 0, a, 2, 4, -1)
+			},
+			want: batchLT0,
+		},
+		{
+			name: "stride",
+			fn: func() {
+				Implementation{}.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, -4, a, 2, 4, 0, a, 2, 4, 2)
+			},
+			want: badBatchStride,
+		},
+		{
+			name: "overlap",
+			fn: func() {
+				Implementation{}.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 4, a, 2, 4, 0, a, 2, 3, 2)
+			},
+			want: badBatchOverlap,
+		},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if r != test.want {
+					t.Errorf("%v: unexpected panic: got %v, want %v", test.name, r, test.want)
+				}
+			}()
+			test.fn()
+		}()
+	}
+}
+
+func TestSgemmStridedBatched(t *testing.T) {
+	const m, n, k, batch = 7, 6, 5, 40
+	a := make([]float32, batch*m*k)
+	b := make([]float32, batch*k*n)
+	for i := range a {
+		a[i] = float32(i%7) - 3
+	}
+	for i := range b {
+		b[i] = float32(i%5) - 2
+	}
+	got := make([]float32, batch*m*n)
+	Implementation{}.SgemmStridedBatched(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, m*k, b, n, k*n, 0, got, n, m*n, batch)
+	for i := 0; i < batch; i++ {
+		want := make([]float32, m*n)
+		Implementation{}.Sgemm(blas.NoTrans, blas.NoTrans, m, n, k, 1, a[i*m*k:], k, b[i*k*n:], n, 0, want, n)
+		for j, v := range want {
+			if got[i*m*n+j] != v {
+				t.Errorf("product %d: unexpected result", i)
+				break
+			}
+		}
+	}
+}
+
+func TestDtrsmBatched(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range []struct {
+		m, n, batch int
+	}{
+		{0, 3, 2},
+		{1, 1, 1},
+		{4, 4, 2000},
+		{6, 3, 50},
+		{3, 7, 50},
+	} {
+		m, n, batch := test.m, test.n, test.batch
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := m
+			if s == blas.Right {
+				k = n
+			}
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, alpha := range []float64{0, 1.5} {
+						prefix := fmt.Sprintf("m=%v,n=%v,batch=%v,s=%v,ul=%v,tA=%v,alpha=%v", m, n, batch, s, ul, tA, alpha)
+						lda, ldb := k+1, n+2
+						strideA := max(k*lda, 1)
+						strideB := max(m*ldb, 1)
+						a := make([]float64, batch*strideA)
+						for i := 0; i < batch; i++ {
+							tri := randtri(k)
+							for r := 0; r < k; r++ {
+								copy(a[i*strideA+r*lda:], tri[r*k:r*k+k])
+							}
+						}
+						b := randmat(batch, strideB, strideB).data
+						want := make([]float64, len(b))
+						copy(want, b)
+						for i := 0; i < batch; i++ {
+							Implementation{}.Dtrsm(s, ul, tA, blas.NonUnit, m, n, alpha, a[i*strideA:], lda, want[i*strideB:], ldb)
+						}
+
+						for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+							got := make([]float64, len(b))
+							copy(got, b)
+							impl.DtrsmStridedBatched(s, ul, tA, blas.NonUnit, m, n, alpha, a, lda, strideA, got, ldb, strideB, batch)
+							if !closeFloat64s(got, want, 1e-12) {
+								t.Errorf("%v, %+v: unexpected strided result", prefix, impl)
+							}
+
+							copy(got, b)
+							as := make([][]float64, batch)
+							bs := make([][]float64, batch)
+							for i := range bs {
+								as[i] = a[i*strideA : (i+1)*strideA]
+								bs[i] = got[i*strideB : (i+1)*strideB]
+							}
+							impl.DtrsmBatched(s, ul, tA, blas.NonUnit, m, n, alpha, as, lda, bs, ldb)
+							if !closeFloat64s(got, want, 1e-12) {
+								t.Errorf("%v, %+v: unexpected pointer-array result", prefix, impl)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDgemvBatched(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range []struct {
+		m, n, batch int
+	}{
+		{0, 3, 2},
+		{1, 1, 1},
+		{8, 8, 2000},
+		{5, 9, 50},
+	} {
+		m, n, batch := test.m, test.n, test.batch
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA == blas.Trans {
+				lenX, lenY = m, n
+			}
+			for _, inc := range level2Incs {
+				prefix := fmt.Sprintf("m=%v,n=%v,batch=%v,tA=%v,incX=%v,incY=%v", m, n, batch, tA, inc.x, inc.y)
+				lda := n + 1
+				strideA := max(m*lda, 1)
+				strideX := (max(lenX, 1)-1)*abs(inc.x) + 1
+				strideY := (max(lenY, 1)-1)*abs(inc.y) + 1
+				a := randmat(batch, strideA, strideA).data
+				x := randvec(batch*strideX, 1)
+				y := randvec(batch*strideY, 1)
+				want := make([]float64, len(y))
+				copy(want, y)
+				for i := 0; i < batch; i++ {
+					Implementation{}.Dgemv(tA, m, n, 1.5, a[i*strideA:], lda, x[i*strideX:], inc.x, 0.5, want[i*strideY:], inc.y)
+				}
+
+				for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+					got := make([]float64, len(y))
+					copy(got, y)
+					impl.DgemvStridedBatched(tA, m, n, 1.5, a, lda, strideA, x, inc.x, strideX, 0.5, got, inc.y, strideY, batch)
+					if !sameFloat64s(got, want) {
+						t.Errorf("%v, %+v: unexpected strided result", prefix, impl)
+					}
+
+					copy(got, y)
+					as := make([][]float64, batch)
+					xs := make([][]float64, batch)
+					ys := make([][]float64, batch)
+					for i := range ys {
+						as[i] = a[i*strideA : (i+1)*strideA]
+						xs[i] = x[i*strideX : (i+1)*strideX]
+						ys[i] = got[i*strideY : (i+1)*strideY]
+					}
+					impl.DgemvBatched(tA, m, n, 1.5, as, lda, xs, inc.x, 0.5, ys, inc.y)
+					if !sameFloat64s(got, want) {
+						t.Errorf("%v, %+v: unexpected pointer-array result", prefix, impl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBatchedEntryPanics(t *testing.T) {
+	good := make([]float64, 16)
+	short := make([]float64, 3)
+	for _, test := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{
+			name: "DgemmBatched",
+			fn: func() {
+				Implementation{}.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, [][]float64{good, good, good}, 2, [][]float64{good, good, short}, 2, 0, [][]float64{good, good, good}, 2)
+			},
+			want: batchEntry("blas: insufficient matrix slice length", 2),
+		},
+		{
+			name: "DgemmStridedBatched",
+			fn: func() {
+				Implementation{}.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, good, 2, 4, good, 2, 4, 0, good, 2, 4, 5)
+			},
+			want: batchEntry("blas: insufficient matrix slice length", 4),
+		},
+		{
+			name: "DtrsmBatched",
+			fn: func() {
+				Implementation{}.DtrsmBatched(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, 2, 2, 1, [][]float64{good, short}, 2, [][]float64{good, good}, 2)
+			},
+			want: batchEntry(badLdA, 1),
+		},
+		{
+			name: "DtrsmStridedBatched",
+			fn: func() {
+				Implementation{}.DtrsmStridedBatched(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, 2, 2, 1, good, 2, 0, good, 2, 4, 5)
+			},
+			want: batchEntry(badLdB, 4),
+		},
+		{
+			name: "DgemvBatched",
+			fn: func() {
+				Implementation{}.DgemvBatched(blas.NoTrans, 2, 2, 1, [][]float64{good, good}, 2, [][]float64{good, good}, 1, 0, [][]float64{good, short[:1]}, 1)
+			},
+			want: batchEntry(badY, 1),
+		},
+		{
+			name: "DgemvStridedBatched",
+			fn: func() {
+				Implementation{}.DgemvStridedBatched(blas.NoTrans, 2, 2, 1, good, 2, 4, good, 1, 2, 0, good, 1, 2, 5)
+			},
+			want: batchEntry(badLdA, 4),
+		},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if r != test.want {
+					t.Errorf("%v: unexpected panic: got %v, want %v", test.name, r, test.want)
+				}
+			}()
+			test.fn()
+		}()
+	}
+}
+
+func benchmarkGemmBatch(b *testing.B, size, batch int, batched bool) {
+	stride := size * size
+	a := randmat(batch, stride, stride).data
+	bm := randmat(batch, stride, stride).data
+	c := make([]float64, batch*stride)
+	impl := Implementation{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if batched {
+			impl.DgemmStridedBatched(blas.NoTrans, blas.NoTrans, size, size, size, 1, a, size, stride, bm, size, stride, 0, c, size, stride, batch)
+			continue
+		}
+		for j := 0; j < batch; j++ {
+			impl.Dgemm(blas.NoTrans, blas.NoTrans, size, size, size, 1, a[j*stride:], size, bm[j*stride:], size, 0, c[j*stride:], size)
+		}
+	}
+}
+
+func BenchmarkDgemmStridedBatched8x8(b *testing.B) { benchmarkGemmBatch(b, 8, 10000, true) }
+func BenchmarkDgemmLoop8x8(b *testing.B)           { benchmarkGemmBatch(b, 8, 10000, false) }
@@ -38,6 +38,14 @@ func BenchmarkDgemmHgHgSm(b *testing.B) {
 	testblas.DgemmBenchmark(b, impl, Hg, Hg, Sm, NT, NT)
 }
 
+func BenchmarkDgemmSmSmHg(b *testing.B) {
+	testblas.DgemmBenchmark(b, impl, Sm, Sm, Hg, NT, NT)
+}
+
+func BenchmarkDgemmSmSmHgTNT(b *testing.B) {
+	testblas.DgemmBenchmark(b, impl, Sm, Sm, Hg, T, NT)
+}
+
 func BenchmarkDgemmMedMedMedTNT(b *testing.B) {
 	testblas.DgemmBenchmark(b, impl, Med, Med, Med, T, NT)
 }
@@ -49,3 +57,14 @@ func BenchmarkDgemmMedMedMedNTT(b *testing.B) {
 func BenchmarkDgemmMedMedMedTT(b *testing.B) {
 	testblas.DgemmBenchmark(b, impl, Med, Med, Med, T, T)
 }
+
+// The parallel benchmarks use as many workers as GOMAXPROCS, so they are
+// intended to be run with several values of -cpu, for example
+//  go test -bench DgemmPar -cpu 1,2,4,8
+func BenchmarkDgemmPar500(b *testing.B) {
+	testblas.DgemmBenchmark(b, impl, 500, 500, 500, NT, NT)
+}
+
+func BenchmarkDgemmParLgMedLg(b *testing.B) {
+	testblas.DgemmBenchmark(b, impl, Lg, Med*2, Lg, NT, NT)
+}
@@ -0,0 +1,303 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"sync/atomic"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/internal/asm/f64"
+)
+
+// Dsgemm computes
+//  C = beta * C + alpha * A * B,
+// where A and B are dense single precision matrices, C is a dense double
+// precision matrix, and alpha and beta are scalars. tA and tB specify whether
+// A or B are transposed. The products are formed and accumulated in double
+// precision.
+func (impl Implementation) Dsgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float32, lda int, b []float32, ldb int, beta float64, c []float64, ldc int) {
+	aTrans, bTrans := checkDsgemm(tA, tB, m, n, k, a, lda, b, ldb)
+	checkMatrix64(m, n, c, ldc)
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
+	impl.dsgemmParallel(aTrans, bTrans, m, n, k, a, lda, b, ldb, alpha, func(i, j, mi, nj int, t []float64, ldt int) {
+		for r := 0; r < mi; r++ {
+			ci := c[(i+r)*ldc+j : (i+r)*ldc+j+nj]
+			ti := t[r*ldt : r*ldt+nj]
+			switch beta {
+			case 0:
+				copy(ci, ti)
+			case 1:
+				f64.AxpyUnitary(1, ti, ci)
+			default:
+				for jj, v := range ti {
+					ci[jj] = beta*ci[jj] + v
+				}
+			}
+		}
+	})
+}
+
+// Sdsgemm computes
+//  C = beta * C + alpha * A * B,
+// where A, B, and C are dense single precision matrices, and alpha and beta
+// are scalars. tA and tB specify whether A or B are transposed. The products
+// are formed and accumulated in double precision and only the elements of C
+// are rounded to single precision, so the accuracy of Sdsgemm does not
+// degrade with k as that of Sgemm does.
+func (impl Implementation) Sdsgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	aTrans, bTrans := checkDsgemm(tA, tB, m, n, k, a, lda, b, ldb)
+	checkMatrix32(m, n, c, ldc)
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
+	beta64 := float64(beta)
+	impl.dsgemmParallel(aTrans, bTrans, m, n, k, a, lda, b, ldb, float64(alpha), func(i, j, mi, nj int, t []float64, ldt int) {
+		for r := 0; r < mi; r++ {
+			ci := c[(i+r)*ldc+j : (i+r)*ldc+j+nj]
+			ti := t[r*ldt : r*ldt+nj]
+			if beta == 0 {
+				for jj, v := range ti {
+					ci[jj] = float32(v)
+				}
+				continue
+			}
+			for jj, v := range ti {
+				ci[jj] = float32(beta64*float64(ci[jj]) + v)
+			}
+		}
+	})
+}
+
+// checkDsgemm checks the arguments of a mixed precision matrix
+// multiplication and returns whether A and B are transposed.
+func checkDsgemm(tA, tB blas.Transpose, m, n, k int, a []float32, lda int, b []float32, ldb int) (aTrans, bTrans bool) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	aTrans = tA == blas.Trans || tA == blas.ConjTrans
+	if aTrans {
+		checkMatrix32(k, m, a, lda)
+	} else {
+		checkMatrix32(m, k, a, lda)
+	}
+	bTrans = tB == blas.Trans || tB == blas.ConjTrans
+	if bTrans {
+		checkMatrix32(n, k, b, ldb)
+	} else {
+		checkMatrix32(k, n, b, ldb)
+	}
+	return aTrans, bTrans
+}
+
+// dsgemmParallel computes alpha * op(A) * op(B) for single precision A and B
+// in double precision, and passes it to store in blocks. store is called
+// once for each mi×nj block starting at row i and column j of the product,
+// which is held in t with stride ldt. The calls may be concurrent but the
+// blocks do not overlap.
+//
+// The partitioning follows the packed scheme of dgemmParallel: the blocks
+// of C are sized by packedBlocks and are shared between the workers, each
+// of which converts the panels of A and B to double precision as it packs
+// them. Since packing also does the conversion it pays off for much smaller
+// products than in dgemmParallel, and only products whose dimensions are
+// all less than minMixedPackDim are computed by dsgemmSerial instead. A block
+// is computed over the whole of the k dimension before it is stored, so that
+// only the final sums are rounded. If there are too few blocks to keep the
+// workers busy, the k dimension is split between them instead.
+func (impl Implementation) dsgemmParallel(aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, alpha float64, store func(i, j, mi, nj int, t []float64, ldt int)) {
+	packed := m >= minMixedPackDim || n >= minMixedPackDim || k >= minMixedPackDim
+	pack := impl.packing()
+	bm, bn := impl.blockSize(), impl.blockSize()
+	if packed {
+		bm, bn = impl.packedBlocks(m, n)
+	}
+	parBlocks := blocks(m, bm) * blocks(n, bn)
+	if parBlocks < minParBlock {
+		nWorkers := impl.maxWorkers()
+		if k/minSplitK < nWorkers {
+			nWorkers = k / minSplitK
+		}
+		if nWorkers > 1 {
+			dsgemmSplitK(nWorkers, pack, aTrans, bTrans, m, n, k, a, lda, b, ldb, alpha, store)
+			return
+		}
+	}
+
+	nWorkers := impl.maxWorkers()
+	if parBlocks < nWorkers {
+		nWorkers = parBlocks
+	}
+	nbj := blocks(n, bn)
+	var next int64
+	workers.parallel(nWorkers, func() {
+		var aPack, bPack []float64
+		if packed {
+			aPack = make([]float64, min(pack.mc, m)*min(pack.kc, k))
+			bPack = make([]float64, min(pack.kc, k)*min(bn, n))
+		}
+		t := make([]float64, min(m, bm)*min(n, bn))
+		for {
+			sub := int(atomic.AddInt64(&next, 1) - 1)
+			if sub >= parBlocks {
+				return
+			}
+			i := (sub / nbj) * bm
+			j := (sub % nbj) * bn
+			mi := min(bm, m-i)
+			nj := min(bn, n-j)
+			if packed {
+				dsgemmBlock(pack.kc, aTrans, bTrans, i, j, mi, nj, 0, k, a, lda, b, ldb, alpha, aPack, bPack, t, nj)
+			} else {
+				dsgemmSerial(aTrans, bTrans, i, j, mi, nj, 0, k, a, lda, b, ldb, alpha, t, nj)
+			}
+			store(i, j, mi, nj, t, nj)
+		}
+	})
+}
+
+// dsgemmSplitK computes alpha * op(A) * op(B) by partitioning the k
+// dimension into nWorkers contiguous ranges as dgemmSplitK does, and passes
+// the sum of the partial products to store as a single block.
+func dsgemmSplitK(nWorkers int, pack gemmPacking, aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, alpha float64, store func(i, j, mi, nj int, t []float64, ldt int)) {
+	work := make([]float64, nWorkers*m*n)
+	var next int64
+	workers.parallel(nWorkers, func() {
+		aPack := make([]float64, min(pack.mc, m)*min(pack.kc, k))
+		bPack := make([]float64, min(pack.kc, k)*min(pack.nc, n))
+		for {
+			w := int(atomic.AddInt64(&next, 1) - 1)
+			if w >= nWorkers {
+				return
+			}
+			k0 := w * k / nWorkers
+			k1 := (w + 1) * k / nWorkers
+			buf := work[w*m*n : (w+1)*m*n]
+			for i := 0; i < m; i += pack.mc {
+				mi := min(pack.mc, m-i)
+				for j := 0; j < n; j += pack.nc {
+					nj := min(pack.nc, n-j)
+					dsgemmBlock(pack.kc, aTrans, bTrans, i, j, mi, nj, k0, k1, a, lda, b, ldb, alpha, aPack, bPack, buf[i*n+j:], n)
+				}
+			}
+		}
+	})
+
+	// Reduce the partial products in order.
+	sum := work[:m*n]
+	for w := 1; w < nWorkers; w++ {
+		f64.AxpyUnitary(1, work[w*m*n:(w+1)*m*n], sum)
+	}
+	store(0, 0, m, n, sum, n)
+}
+
+// dsgemmBlock computes the mi×nj block starting at row i and column j of
+//  alpha * op(A)[:, k0:k1] * op(B)[k0:k1, :]
+// in double precision and stores it in t with stride ldt. The kc deep panels
+// of A and B are converted to double precision as they are packed into aPack
+// and bPack, and multiplied with dgemmKernel.
+func dsgemmBlock(kc int, aTrans, bTrans bool, i, j, mi, nj, k0, k1 int, a []float32, lda int, b []float32, ldb int, alpha float64, aPack, bPack, t []float64, ldt int) {
+	for r := 0; r < mi; r++ {
+		row := t[r*ldt : r*ldt+nj]
+		for jj := range row {
+			row[jj] = 0
+		}
+	}
+	for pc := k0; pc < k1; pc += kc {
+		lenk := min(kc, k1-pc)
+		dsgemmPackB(bTrans, lenk, nj, b, ldb, pc, j, bPack)
+		dsgemmPackA(aTrans, mi, lenk, a, lda, i, pc, alpha, aPack)
+		dgemmKernel(mi, nj, lenk, aPack, bPack, t, ldt)
+	}
+}
+
+// dsgemmSerial computes the same block as dsgemmBlock without packing, by
+// converting the elements of A and B to double precision as they are used.
+// It is used for products too small for packing to pay off.
+func dsgemmSerial(aTrans, bTrans bool, i, j, mi, nj, k0, k1 int, a []float32, lda int, b []float32, ldb int, alpha float64, t []float64, ldt int) {
+	for r := 0; r < mi; r++ {
+		row := t[r*ldt : r*ldt+nj]
+		if bTrans {
+			for jj := range row {
+				bRow := b[(j+jj)*ldb+k0 : (j+jj)*ldb+k1]
+				var sum float64
+				if aTrans {
+					for l, v := range bRow {
+						sum += float64(a[(k0+l)*lda+i+r]) * float64(v)
+					}
+				} else {
+					for l, v := range a[(i+r)*lda+k0 : (i+r)*lda+k1] {
+						sum += float64(v) * float64(bRow[l])
+					}
+				}
+				row[jj] = alpha * sum
+			}
+			continue
+		}
+		for jj := range row {
+			row[jj] = 0
+		}
+		for l := k0; l < k1; l++ {
+			var av float64
+			if aTrans {
+				av = alpha * float64(a[l*lda+i+r])
+			} else {
+				av = alpha * float64(a[(i+r)*lda+l])
+			}
+			if av == 0 {
+				continue
+			}
+			for jj, v := range b[l*ldb+j : l*ldb+j+nj] {
+				row[jj] += av * float64(v)
+			}
+		}
+	}
+}
+
+// dsgemmPackA is dgemmPackA for single precision A.
+func dsgemmPackA(aTrans bool, mc, kc int, a []float32, lda, i, p int, alpha float64, dst []float64) {
+	var o int
+	for ir := 0; ir < mc; ir += mrBlock {
+		mr := min(mrBlock, mc-ir)
+		for l := 0; l < kc; l++ {
+			if aTrans {
+				for r, v := range a[(p+l)*lda+i+ir : (p+l)*lda+i+ir+mr] {
+					dst[o+r] = alpha * float64(v)
+				}
+			} else {
+				for r := 0; r < mr; r++ {
+					dst[o+r] = alpha * float64(a[(i+ir+r)*lda+p+l])
+				}
+			}
+			o += mr
+		}
+	}
+}
+
+// dsgemmPackB is dgemmPackB for single precision B.
+func dsgemmPackB(bTrans bool, kc, nc int, b []float32, ldb, p, j int, dst []float64) {
+	for l := 0; l < kc; l++ {
+		row := dst[l*nc : l*nc+nc]
+		if bTrans {
+			for jj := range row {
+				row[jj] = float64(b[(j+jj)*ldb+p+l])
+			}
+		} else {
+			for jj, v := range b[(p+l)*ldb+j : (p+l)*ldb+j+nc] {
+				row[jj] = float64(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// randmat32 returns a random r×c single precision matrix with the given
+// stride, and the same matrix in double precision.
+func randmat32(r, c, stride int) ([]float32, []float64) {
+	a32 := make([]float32, r*stride+c)
+	a64 := make([]float64, len(a32))
+	for i := range a32 {
+		a32[i] = float32(rand.NormFloat64())
+		a64[i] = float64(a32[i])
+	}
+	return a32, a64
+}
+
+func TestDsgemm(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range []struct{ m, n, k int }{
+		{1, 1, 1},
+		{3, 4, 0},
+		{7, 9, 11},
+		{5, 7, 130},
+		{70, 3, 5},
+		{150, 600, 20},
+		{3, 5, 5000},
+	} {
+		m, n, k := test.m, test.n, test.k
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				ar, ac := m, k
+				if tA == blas.Trans {
+					ar, ac = k, m
+				}
+				br, bc := k, n
+				if tB == blas.Trans {
+					br, bc = n, k
+				}
+				lda, ldb, ldc := ac+1, bc+2, n+3
+				a32, a64 := randmat32(ar, ac, lda)
+				b32, b64 := randmat32(br, bc, ldb)
+				c32, c64 := randmat32(m, n, ldc)
+				for _, ab := range []struct{ alpha, beta float64 }{{1.5, 0}, {1, 1}, {-2, 0.5}, {0, 2}} {
+					alpha, beta := ab.alpha, ab.beta
+					want := make([]float64, len(c64))
+					copy(want, c64)
+					Implementation{Serial: true}.Dgemm(tA, tB, m, n, k, alpha, a64, lda, b64, ldb, beta, want, ldc)
+					for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {BlockSize: 30, MaxWorkers: 2}, {}} {
+						prefix := fmt.Sprintf("m=%v,n=%v,k=%v,tA=%v,tB=%v,alpha=%v,beta=%v,%+v", m, n, k, tA, tB, alpha, beta, impl)
+
+						got := make([]float64, len(c64))
+						copy(got, c64)
+						impl.Dsgemm(tA, tB, m, n, k, alpha, a32, lda, b32, ldb, beta, got, ldc)
+						if !closeFloat64s(got, want, 1e-12) {
+							t.Errorf("%v: unexpected Dsgemm result", prefix)
+						}
+						if !sameOutside(got, c64, m, n, ldc) {
+							t.Errorf("%v: Dsgemm modified elements outside C", prefix)
+						}
+
+						got32 := make([]float32, len(c32))
+						copy(got32, c32)
+						impl.Sdsgemm(tA, tB, m, n, k, float32(alpha), a32, lda, b32, ldb, float32(beta), got32, ldc)
+						for i := 0; i < m; i++ {
+							for j := 0; j < n; j++ {
+								g, w := got32[i*ldc+j], want[i*ldc+j]
+								if math.Abs(float64(g)-w) > 1e-6*math.Max(1, math.Abs(w)) {
+									t.Errorf("%v: unexpected Sdsgemm element (%d,%d): got %v, want %v", prefix, i, j, g, w)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// sameOutside returns whether the elements of a that lie outside the m×n
+// matrix with stride ld are the same as those of b.
+func sameOutside(a, b []float64, m, n, ld int) bool {
+	for i, v := range a {
+		if i/ld < m && i%ld < n {
+			continue
+		}
+		if math.Float64bits(v) != math.Float64bits(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSdsgemmLongK(t *testing.T) {
+	// The sum of k ones in single precision stops growing at 2^24, but
+	// the double precision accumulation is exact.
+	const k = 1<<24 + 1000
+	a := make([]float32, k)
+	for i := range a {
+		a[i] = 1
+	}
+	for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 4}} {
+		c := []float32{0}
+		impl.Sdsgemm(blas.NoTrans, blas.Trans, 1, 1, k, 1, a, k, a, k, 0, c, 1)
+		if want := float32(k); c[0] != want {
+			t.Errorf("%+v: unexpected result: got %v, want %v", impl, c[0], want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func BenchmarkDsgemmSmSmSm(b *testing.B) {
+	testblas.DsgemmBenchmark(b, impl, Sm, Sm, Sm, NT, NT)
+}
+
+func BenchmarkDsgemmSmSmSmSerial(b *testing.B) {
+	testblas.DsgemmBenchmark(b, Implementation{Serial: true}, Sm, Sm, Sm, NT, NT)
+}
+
+func BenchmarkDsgemmMedMedMed(b *testing.B) {
+	testblas.DsgemmBenchmark(b, impl, Med, Med, Med, NT, NT)
+}
+
+func BenchmarkDsgemmMedMedMedTT(b *testing.B) {
+	testblas.DsgemmBenchmark(b, impl, Med, Med, Med, T, T)
+}
+
+func BenchmarkDsgemmLgLgLg(b *testing.B) {
+	testblas.DsgemmBenchmark(b, impl, Lg, Lg, Lg, NT, NT)
+}
@@ -0,0 +1,13 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This repository is no longer maintained.
+// Development has moved to https://github.com/gonum/gonum.
+//
+// Package cmplx64 provides complex64 versions of standard library math/cmplx
+// package routines used by gonum/blas/native.
+package cmplx64
+
+// Conj returns the complex conjugate of x.
+func Conj(x complex64) complex64 { return complex(real(x), -imag(x)) }
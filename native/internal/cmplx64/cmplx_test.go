@@ -0,0 +1,21 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmplx64
+
+import (
+	"math/cmplx"
+	"testing"
+	"testing/quick"
+)
+
+func TestConj(t *testing.T) {
+	f := func(x complex64) bool {
+		y := Conj(x)
+		return y == complex64(cmplx.Conj(complex128(x)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -16,7 +16,7 @@ var _ blas.Float64Level1 = Implementation{}
 // Dnrm2 computes the Euclidean norm of a vector,
 //  sqrt(\sum_i x[i] * x[i]).
 // This function returns 0 if incX is negative.
-func (Implementation) Dnrm2(n int, x []float64, incX int) float64 {
+func (impl Implementation) Dnrm2(n int, x []float64, incX int) float64 {
 	if incX < 1 {
 		if incX == 0 {
 			panic(zeroIncX)
@@ -37,6 +37,9 @@ func (Implementation) Dnrm2(n int, x []float64, incX int) float64 {
 			panic(negativeN)
 		}
 	}
+	if impl.Reproducible {
+		return reproducibleNrm2(n, x, incX)
+	}
 	var (
 		scale      float64 = 0
 		sumSquares float64 = 1
@@ -88,7 +91,7 @@ func (Implementation) Dnrm2(n int, x []float64, incX int) float64 {
 // Dasum computes the sum of the absolute values of the elements of x.
 //  \sum_i |x[i]|
 // Dasum returns 0 if incX is negative.
-func (Implementation) Dasum(n int, x []float64, incX int) float64 {
+func (impl Implementation) Dasum(n int, x []float64, incX int) float64 {
 	var sum float64
 	if n < 0 {
 		panic(negativeN)
@@ -102,6 +105,9 @@ func (Implementation) Dasum(n int, x []float64, incX int) float64 {
 	if incX > 0 && (n-1)*incX >= len(x) {
 		panic(badX)
 	}
+	if impl.Reproducible {
+		return reproducibleAsum(n, x, incX)
+	}
 	if incX == 1 {
 		x = x[:n]
 		for _, v := range x {
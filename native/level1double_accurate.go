@@ -0,0 +1,270 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+var _ blas.Float64Accurate = Implementation{}
+
+// The compensated routines follow Ogita, Rump and Oishi, "Accurate Sum and
+// Dot Product", SIAM J. Sci. Comput. 26(6), 2005. Sums and products are
+// computed together with their exact rounding errors by the error-free
+// transformations twoSum and twoProd, and the errors are accumulated
+// separately and added to the result at the end.
+//
+// The intermediate results are converted explicitly to float64 so that they
+// are not fused into multiply-add instructions, which would break the
+// error-free transformations.
+
+const (
+	// eps is the unit roundoff of float64.
+	eps = 1.0 / (1 << 53)
+
+	// splitFactor is 2^27 + 1, used by twoProd to split a float64 into two
+	// halves of at most 26 significant bits.
+	splitFactor = 1<<27 + 1
+
+	// splitMax is 2^996, the largest magnitude that split can handle
+	// without overflow. twoProd scales larger operands down by splitScale.
+	// It is written as a product since 1<<996 exceeds the range of untyped
+	// integer constants.
+	splitMax   = 1.0 * (1 << 500) * (1 << 496)
+	splitScale = 1 << 28
+)
+
+// twoSum returns s = fl(a + b) and e such that s + e = a + b exactly. If s
+// is infinite or NaN, e is zero.
+func twoSum(a, b float64) (s, e float64) {
+	s = float64(a + b)
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		return s, 0
+	}
+	bv := float64(s - a)
+	e = float64(a-float64(s-bv)) + float64(b-bv)
+	return s, e
+}
+
+// split returns hi and lo with at most 26 significant bits such that
+// hi + lo = a exactly, provided |a| <= splitMax.
+func split(a float64) (hi, lo float64) {
+	c := float64(splitFactor * a)
+	hi = float64(c - float64(c-a))
+	return hi, float64(a - hi)
+}
+
+// twoProd returns p = fl(a * b) and e such that p + e = a * b exactly,
+// provided no underflow occurs. If p is infinite or NaN, e is zero.
+func twoProd(a, b float64) (p, e float64) {
+	p = float64(a * b)
+	if math.IsInf(p, 0) || math.IsNaN(p) {
+		return p, 0
+	}
+	// An operand that is too large for split is scaled down by a power of
+	// two, which is exact, and the error is scaled back up. Since p is
+	// finite, at most one of the operands is that large.
+	switch {
+	case math.Abs(a) > splitMax:
+		e = prodError(float64(a/splitScale), b, float64(p/splitScale))
+		return p, float64(e * splitScale)
+	case math.Abs(b) > splitMax:
+		e = prodError(a, float64(b/splitScale), float64(p/splitScale))
+		return p, float64(e * splitScale)
+	}
+	return p, prodError(a, b, p)
+}
+
+// prodError returns a*b - p exactly, where p = fl(a * b) and the magnitudes
+// of a and b are at most splitMax.
+func prodError(a, b, p float64) float64 {
+	ahi, alo := split(a)
+	bhi, blo := split(b)
+	return float64(float64(float64(float64(ahi*bhi)-p)+float64(ahi*blo))+float64(alo*bhi)) + float64(alo*blo)
+}
+
+// dot2 accumulates a dot product together with the rounding errors of its
+// products and sums.
+type dot2 struct {
+	p float64 // sum of the rounded products
+	s float64 // sum of the rounding errors
+}
+
+// add adds the product a*b to the dot product.
+func (d *dot2) add(a, b float64) {
+	h, r := twoProd(a, b)
+	var q float64
+	d.p, q = twoSum(d.p, h)
+	d.s += float64(q + r)
+}
+
+// result returns the dot product rounded to float64.
+func (d dot2) result() float64 {
+	return float64(d.p + d.s)
+}
+
+// axpby returns alpha * d + beta * y computed as if in twice the working
+// precision and then rounded. y is not referenced if beta is zero.
+func (d dot2) axpby(alpha, beta, y float64) float64 {
+	h, r := twoProd(alpha, d.p)
+	r += float64(alpha * d.s)
+	if beta != 0 {
+		h2, r2 := twoProd(beta, y)
+		var q float64
+		h, q = twoSum(h, h2)
+		r += float64(q + r2)
+	}
+	return float64(h + r)
+}
+
+// gamma returns the error bound constant n*eps / (1 - n*eps).
+func gamma(n int) float64 {
+	nu := float64(n) * eps
+	return nu / (1 - nu)
+}
+
+// DdotCompensated computes the dot product of the two vectors
+//  \sum_i x[i]*y[i]
+// as accurately as if it were computed in twice the working precision and
+// then rounded. The error of the result is bounded by
+//  eps*|x^T y| + gamma(n)^2 * |x|^T |y|
+// where eps is the unit roundoff and gamma(n) = n*eps/(1 - n*eps).
+// Underflow in the intermediate products is not accounted for. If a product
+// or the sum overflows, the result is infinite or NaN as for Ddot.
+func (Implementation) DdotCompensated(n int, x []float64, incX int, y []float64, incY int) float64 {
+	dot, _ := ddotCompensated(n, x, incX, y, incY, false)
+	return dot
+}
+
+// DdotBound computes the dot product of the two vectors as DdotCompensated
+// does, and returns it together with an upper bound on its absolute error.
+// The bound is computed from the result and from |x|^T |y|, and neglects
+// underflow.
+func (Implementation) DdotBound(n int, x []float64, incX int, y []float64, incY int) (dot, bound float64) {
+	return ddotCompensated(n, x, incX, y, incY, true)
+}
+
+func ddotCompensated(n int, x []float64, incX int, y []float64, incY int, wantBound bool) (dot, bound float64) {
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if n <= 0 {
+		if n == 0 {
+			return 0, 0
+		}
+		panic(negativeN)
+	}
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	if ix >= len(x) || ix+(n-1)*incX >= len(x) {
+		panic(badLenX)
+	}
+	if iy >= len(y) || iy+(n-1)*incY >= len(y) {
+		panic(badLenY)
+	}
+
+	var d dot2
+	var abs float64
+	for i := 0; i < n; i++ {
+		d.add(x[ix], y[iy])
+		if wantBound {
+			abs += math.Abs(float64(x[ix] * y[iy]))
+		}
+		ix += incX
+		iy += incY
+	}
+	dot = d.result()
+	if !wantBound {
+		return dot, 0
+	}
+	if 2*float64(n)*eps >= 1 {
+		return dot, math.Inf(1)
+	}
+	// The computed abs is at least (1 - gamma(n+1)) times |x|^T |y|, and
+	// the final factor covers the rounding errors in computing the bound.
+	g := gamma(n)
+	bound = (eps*math.Abs(dot) + g*g*abs/(1-gamma(n+1))) / (1 - eps)
+	return dot, bound * (1 + 4*eps)
+}
+
+// DsdotCompensated computes the dot product of the two vectors
+//  \sum_i x[i]*y[i]
+// in float64 as accurately as if it were computed in twice the working
+// precision and then rounded. The products of float32 values are exact in
+// float64, so only the summation is compensated.
+func (Implementation) DsdotCompensated(n int, x []float32, incX int, y []float32, incY int) float64 {
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if n <= 0 {
+		if n == 0 {
+			return 0
+		}
+		panic(negativeN)
+	}
+	var ix, iy int
+	if incX < 0 {
+		ix = (-n + 1) * incX
+	}
+	if incY < 0 {
+		iy = (-n + 1) * incY
+	}
+	if ix >= len(x) || ix+(n-1)*incX >= len(x) {
+		panic(badLenX)
+	}
+	if iy >= len(y) || iy+(n-1)*incY >= len(y) {
+		panic(badLenY)
+	}
+
+	var sum, c float64
+	for i := 0; i < n; i++ {
+		var e float64
+		sum, e = twoSum(sum, float64(float64(x[ix])*float64(y[iy])))
+		c += e
+		ix += incX
+		iy += incY
+	}
+	return float64(sum + c)
+}
+
+// DasumCompensated computes the sum of the absolute values of the elements
+// of x
+//  \sum_i |x[i]|
+// as accurately as if it were computed in twice the working precision and
+// then rounded. DasumCompensated returns 0 if incX is negative.
+func (Implementation) DasumCompensated(n int, x []float64, incX int) float64 {
+	if n < 0 {
+		panic(negativeN)
+	}
+	if incX < 1 {
+		if incX == 0 {
+			panic(zeroIncX)
+		}
+		return 0
+	}
+	if incX > 0 && (n-1)*incX >= len(x) {
+		panic(badX)
+	}
+	var sum, c float64
+	for i := 0; i < n; i++ {
+		var e float64
+		sum, e = twoSum(sum, math.Abs(x[i*incX]))
+		c += e
+	}
+	return float64(sum + c)
+}
@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// illDot returns vectors x and y of length n whose dot product has a
+// condition number of about 2^cond, generated as described in Ogita, Rump
+// and Oishi, "Accurate Sum and Dot Product", Algorithm 6.1.
+func illDot(rnd *rand.Rand, n, cond int) (x, y []float64) {
+	x = make([]float64, n)
+	y = make([]float64, n)
+	n2 := n / 2
+	for i := 0; i < n2; i++ {
+		e := 0
+		if i > 0 {
+			e = rnd.Intn(cond/2 + 1)
+		}
+		if i == n2-1 {
+			e = cond / 2
+		}
+		x[i] = math.Ldexp(2*rnd.Float64()-1, e)
+		y[i] = math.Ldexp(2*rnd.Float64()-1, e)
+	}
+	for i := n2; i < n; i++ {
+		e := cond / 2 * (n - 1 - i) / (n - n2)
+		x[i] = math.Ldexp(2*rnd.Float64()-1, e)
+		y[i] = (math.Ldexp(2*rnd.Float64()-1, e) - exactDot(x[:i], y[:i])) / x[i]
+	}
+	return permuted(rnd, x, y)
+}
+
+// dotError returns |got - x^T y| computed exactly, and |x|^T |y|.
+func dotError(got float64, x, y []float64) (err, abs float64) {
+	sum := new(big.Float).SetPrec(2048)
+	for i := range x {
+		p := new(big.Float).SetPrec(2048).SetFloat64(x[i])
+		p.Mul(p, new(big.Float).SetFloat64(y[i]))
+		sum.Sub(sum, p)
+		abs += math.Abs(x[i] * y[i])
+	}
+	sum.Add(sum, new(big.Float).SetFloat64(got))
+	err, _ = sum.Abs(sum).Float64()
+	return err, abs * (1 + 2*gamma(len(x)))
+}
+
+func TestDdotCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, n := range []int{1, 2, 6, 100, 1001} {
+		for _, cond := range []int{0, 50, 100, 150} {
+			if n < 6 && cond > 0 {
+				continue
+			}
+			x, y := illDot(rnd, n, cond)
+			exact := exactDot(x, y)
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {-3, -2}} {
+				sx := strided(x, inc.x, 1)
+				sy := strided(y, inc.y, 0)
+
+				got := impl.DdotCompensated(n, sx, inc.x, sy, inc.y)
+				err, abs := dotError(got, x, y)
+				g := gamma(n)
+				if tol := 2*eps*math.Abs(exact) + g*g*abs; err > tol {
+					t.Errorf("n=%v,cond=%v,incX=%v,incY=%v: inaccurate DdotCompensated: got %v, want %v", n, cond, inc.x, inc.y, got, exact)
+				}
+
+				dot, bound := impl.DdotBound(n, sx, inc.x, sy, inc.y)
+				if math.Float64bits(dot) != math.Float64bits(got) {
+					t.Errorf("n=%v,cond=%v,incX=%v,incY=%v: DdotBound result differs from DdotCompensated", n, cond, inc.x, inc.y)
+				}
+				if bound < err {
+					t.Errorf("n=%v,cond=%v,incX=%v,incY=%v: bound too small: got %v, error %v", n, cond, inc.x, inc.y, bound, err)
+				}
+				if bound > 4*eps*math.Abs(exact)+4*g*g*abs {
+					t.Errorf("n=%v,cond=%v,incX=%v,incY=%v: bound too large: got %v, error %v", n, cond, inc.x, inc.y, bound, err)
+				}
+			}
+		}
+	}
+}
+
+func TestDdotCompensatedCancellation(t *testing.T) {
+	// The naive dot product of these vectors is 0.
+	x := []float64{1e100, 1, -1e100}
+	y := []float64{1, 1, 1}
+	if got := (Implementation{}).DdotCompensated(len(x), x, 1, y, 1); got != 1 {
+		t.Errorf("unexpected result: got %v, want 1", got)
+	}
+	x = []float64{1 + 1.0/(1<<30), 1 + 1.0/(1<<30)}
+	y = []float64{1 - 1.0/(1<<30), -1}
+	want := -1.0/(1<<30) - 1.0/(1<<60)
+	if got := (Implementation{}).DdotCompensated(len(x), x, 1, y, 1); got != want {
+		t.Errorf("unexpected result: got %v, want %v", got, want)
+	}
+	if got, bound := (Implementation{}).DdotBound(0, nil, 1, nil, 1); got != 0 || bound != 0 {
+		t.Errorf("unexpected result for n=0: got %v±%v", got, bound)
+	}
+}
+
+// closeSpecial returns whether got is within 2*eps of want relative to
+// want, or both are the same infinity, or both are NaN.
+func closeSpecial(got, want float64) bool {
+	if math.IsNaN(want) || math.IsInf(want, 0) {
+		return got == want || math.IsNaN(got) && math.IsNaN(want)
+	}
+	return math.Abs(got-want) <= 2*eps*math.Abs(want)
+}
+
+func TestDdotCompensatedSpecial(t *testing.T) {
+	inf := math.Inf(1)
+	impl := Implementation{}
+	for i, test := range []struct {
+		x, y []float64
+		want float64
+	}{
+		// Operands too large to be split without overflow.
+		{x: []float64{1e305, 1}, y: []float64{1e-10, 1}, want: exactDot([]float64{1e305, 1}, []float64{1e-10, 1})},
+		{x: []float64{1e-10, 1}, y: []float64{1e305, 1}, want: exactDot([]float64{1e-10, 1}, []float64{1e305, 1})},
+		{x: []float64{1e300, -3}, y: []float64{3e-300, 1}, want: exactDot([]float64{1e300, -3}, []float64{3e-300, 1})},
+		{x: []float64{math.MaxFloat64, -math.MaxFloat64}, y: []float64{0.5, 0.5}, want: 0},
+		{x: []float64{math.MaxFloat64, 1}, y: []float64{1 - eps, 1}, want: exactDot([]float64{math.MaxFloat64, 1}, []float64{1 - eps, 1})},
+
+		// Infinite inputs and overflow.
+		{x: []float64{inf}, y: []float64{1}, want: inf},
+		{x: []float64{1, inf}, y: []float64{2, 1}, want: inf},
+		{x: []float64{1, -inf}, y: []float64{2, 1}, want: -inf},
+		{x: []float64{1e200, 1e200}, y: []float64{1e200, 1}, want: inf},
+		{x: []float64{math.MaxFloat64, math.MaxFloat64}, y: []float64{1, 1}, want: inf},
+		{x: []float64{inf, -inf}, y: []float64{1, 1}, want: math.NaN()},
+		{x: []float64{math.NaN(), 1}, y: []float64{1, 1}, want: math.NaN()},
+	} {
+		got := impl.DdotCompensated(len(test.x), test.x, 1, test.y, 1)
+		if !closeSpecial(got, test.want) {
+			t.Errorf("case %d: unexpected result: got %v, want %v", i, got, test.want)
+		}
+		got, _ = impl.DdotBound(len(test.x), test.x, 1, test.y, 1)
+		if !closeSpecial(got, test.want) {
+			t.Errorf("case %d: unexpected DdotBound result: got %v, want %v", i, got, test.want)
+		}
+	}
+
+	f32inf := float32(math.Inf(1))
+	if got := impl.DsdotCompensated(2, []float32{1, f32inf}, 1, []float32{2, 1}, 1); got != inf {
+		t.Errorf("unexpected DsdotCompensated result: got %v, want +Inf", got)
+	}
+	if got := impl.DasumCompensated(2, []float64{-inf, 1}, 1); got != inf {
+		t.Errorf("unexpected DasumCompensated result: got %v, want +Inf", got)
+	}
+	if got := impl.DasumCompensated(2, []float64{math.MaxFloat64, -math.MaxFloat64}, 1); got != inf {
+		t.Errorf("unexpected DasumCompensated result for overflow: got %v, want +Inf", got)
+	}
+}
+
+func TestDsdotCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, n := range []int{1, 2, 6, 100, 1001} {
+		x64, y64 := illDot(rnd, n, 40)
+		x := make([]float32, n)
+		y := make([]float32, n)
+		for i := range x {
+			x[i] = float32(x64[i])
+			y[i] = float32(y64[i])
+			x64[i] = float64(x[i])
+			y64[i] = float64(y[i])
+		}
+		exact := exactDot(x64, y64)
+		for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {-3, -2}} {
+			sx := make([]float32, 0)
+			for _, v := range strided(x64, inc.x, 0) {
+				sx = append(sx, float32(v))
+			}
+			sy := make([]float32, 0)
+			for _, v := range strided(y64, inc.y, 0) {
+				sy = append(sy, float32(v))
+			}
+			got := impl.DsdotCompensated(n, sx, inc.x, sy, inc.y)
+			err, abs := dotError(got, x64, y64)
+			g := gamma(n)
+			if tol := 2*eps*math.Abs(exact) + g*g*abs; err > tol {
+				t.Errorf("n=%v,incX=%v,incY=%v: inaccurate result: got %v, want %v", n, inc.x, inc.y, got, exact)
+			}
+		}
+	}
+}
+
+func TestDasumCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impl := Implementation{}
+	for _, n := range []int{0, 1, 2, 7, 100, 1001} {
+		x := wideVec(rnd, n, 60)
+		sum := new(big.Float).SetPrec(2048)
+		for _, v := range x {
+			sum.Add(sum, new(big.Float).SetFloat64(math.Abs(v)))
+		}
+		want, _ := sum.Float64()
+		for _, inc := range []int{1, 2, 5} {
+			got := impl.DasumCompensated(n, strided(x, inc, 1), inc)
+			if math.Abs(got-want) > 2*eps*want {
+				t.Errorf("n=%v,inc=%v: inaccurate result: got %v, want %v", n, inc, got, want)
+			}
+		}
+	}
+	if got := impl.DasumCompensated(2, []float64{1, 2}, -1); got != 0 {
+		t.Errorf("unexpected result for negative increment: got %v, want 0", got)
+	}
+}
+
+func TestAccuratePanics(t *testing.T) {
+	impl := Implementation{}
+	x := []float64{1, 2, 3}
+	xs := []float32{1, 2, 3}
+	for _, test := range []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"DdotCompensated zero incX", func() { impl.DdotCompensated(3, x, 0, x, 1) }, zeroIncX},
+		{"DdotCompensated zero incY", func() { impl.DdotCompensated(3, x, 1, x, 0) }, zeroIncY},
+		{"DdotCompensated negative n", func() { impl.DdotCompensated(-1, x, 1, x, 1) }, negativeN},
+		{"DdotCompensated short x", func() { impl.DdotCompensated(3, x, 2, x, 1) }, badLenX},
+		{"DdotBound short y", func() { impl.DdotBound(3, x, 1, x, -2) }, badLenY},
+		{"DsdotCompensated short x", func() { impl.DsdotCompensated(4, xs, 1, xs, 1) }, badLenX},
+		{"DasumCompensated zero incX", func() { impl.DasumCompensated(3, x, 0) }, zeroIncX},
+		{"DasumCompensated short x", func() { impl.DasumCompensated(2, x, 3) }, badX},
+	} {
+		panics := func() (msg interface{}) {
+			defer func() { msg = recover() }()
+			test.fn()
+			return nil
+		}
+		if got := panics(); got != test.want {
+			t.Errorf("%v: unexpected panic: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -18,8 +18,6 @@ var _ blas.Float32Level1 = Implementation{}
 // Snrm2 computes the Euclidean norm of a vector,
 //  sqrt(\sum_i x[i] * x[i]).
 // This function returns 0 if incX is negative.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Snrm2(n int, x []float32, incX int) float32 {
 	if incX < 1 {
 		if incX == 0 {
@@ -92,8 +90,6 @@ func (Implementation) Snrm2(n int, x []float32, incX int) float32 {
 // Sasum computes the sum of the absolute values of the elements of x.
 //  \sum_i |x[i]|
 // Sasum returns 0 if incX is negative.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sasum(n int, x []float32, incX int) float32 {
 	var sum float32
 	if n < 0 {
@@ -124,8 +120,6 @@ func (Implementation) Sasum(n int, x []float32, incX int) float32 {
 // Isamax returns the index of an element of x with the largest absolute value.
 // If there are multiple such indices the earliest is returned.
 // Isamax returns -1 if n == 0.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Isamax(n int, x []float32, incX int) int {
 	if incX < 1 {
 		if incX == 0 {
@@ -174,8 +168,6 @@ func (Implementation) Isamax(n int, x []float32, incX int) int {
 
 // Sswap exchanges the elements of two vectors.
 //  x[i], y[i] = y[i], x[i] for all i
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sswap(n int, x []float32, incX int, y []float32, incY int) {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -218,8 +210,6 @@ func (Implementation) Sswap(n int, x []float32, incX int, y []float32, incY int)
 
 // Scopy copies the elements of x into the elements of y.
 //  y[i] = x[i] for all i
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Scopy(n int, x []float32, incX int, y []float32, incY int) {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -259,8 +249,6 @@ func (Implementation) Scopy(n int, x []float32, incX int, y []float32, incY int)
 
 // Saxpy adds alpha times x to y
 //  y[i] += alpha * x[i] for all i
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -323,8 +311,6 @@ func (Implementation) Saxpy(n int, alpha float32, x []float32, incX int, y []flo
 // technical manual regarding the sign for r when a or b are zero.
 // Srotg agrees with the definition in the manual and other
 // common BLAS implementations.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Srotg(a, b float32) (c, s, r, z float32) {
 	if b == 0 && a == 0 {
 		return 1, 0, a, 0
@@ -353,8 +339,6 @@ func (Implementation) Srotg(a, b float32) (c, s, r, z float32) {
 // Srotmg computes the modified Givens rotation. See
 // http://www.netlib.org/lapack/explore-html/df/deb/drotmg_8f.html
 // for more details.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Srotmg(d1, d2, x1, y1 float32) (p blas.SrotmParams, rd1, rd2, rx1 float32) {
 	var p1, p2, q1, q2, u float32
 
@@ -477,8 +461,6 @@ func (Implementation) Srotmg(d1, d2, x1, y1 float32) (p blas.SrotmParams, rd1, r
 // Srot applies a plane transformation.
 //  x[i] = c * x[i] + s * y[i]
 //  y[i] = c * y[i] - s * x[i]
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Srot(n int, x []float32, incX int, y []float32, incY int, c float32, s float32) {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -523,8 +505,6 @@ func (Implementation) Srot(n int, x []float32, incX int, y []float32, incY int,
 }
 
 // Srotm applies the modified Givens rotation to the 2×n matrix.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Srotm(n int, x []float32, incX int, y []float32, incY int, p blas.SrotmParams) {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -593,8 +573,6 @@ func (Implementation) Srotm(n int, x []float32, incX int, y []float32, incY int,
 // Sscal scales x by alpha.
 //  x[i] *= alpha
 // Sscal has no effect if incX < 0.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sscal(n int, alpha float32, x []float32, incX int) {
 	if incX < 1 {
 		if incX == 0 {
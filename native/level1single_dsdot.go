@@ -12,8 +12,6 @@ import (
 
 // Dsdot computes the dot product of the two vectors
 //  \sum_i x[i]*y[i]
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -12,8 +12,6 @@ import (
 
 // Sdot computes the dot product of the two vectors
 //  \sum_i x[i]*y[i]
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
 	if incX == 0 {
 		panic(zeroIncX)
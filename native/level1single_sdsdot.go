@@ -12,8 +12,6 @@ import (
 
 // Sdsdot computes the dot product of the two vectors plus a constant
 //  alpha + \sum_i x[i]*y[i]
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
 	if incX == 0 {
 		panic(zeroIncX)
@@ -23,7 +21,7 @@ func (Implementation) Sdsdot(n int, alpha float32, x []float32, incX int, y []fl
 	}
 	if n <= 0 {
 		if n == 0 {
-			return 0
+			return alpha
 		}
 		panic(negativeN)
 	}
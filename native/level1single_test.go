@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func TestSdot(t *testing.T) {
+	testblas.SdotTest(t, impl)
+}
+
+func TestDsdot(t *testing.T) {
+	testblas.DsdotTest(t, impl)
+}
+
+func TestSdsdot(t *testing.T) {
+	testblas.SdsdotTest(t, impl)
+}
+
+func TestSnrm2(t *testing.T) {
+	testblas.Snrm2Test(t, impl)
+}
+
+func TestSasum(t *testing.T) {
+	testblas.SasumTest(t, impl)
+}
+
+func TestIsamax(t *testing.T) {
+	testblas.IsamaxTest(t, impl)
+}
+
+func TestSswap(t *testing.T) {
+	testblas.SswapTest(t, impl)
+}
+
+func TestScopy(t *testing.T) {
+	testblas.ScopyTest(t, impl)
+}
+
+func TestSaxpy(t *testing.T) {
+	testblas.SaxpyTest(t, impl)
+}
+
+func TestSrotg(t *testing.T) {
+	testblas.SrotgTest(t, impl)
+}
+
+func TestSrotmg(t *testing.T) {
+	testblas.SrotmgTest(t, impl)
+}
+
+func TestSrot(t *testing.T) {
+	testblas.SrotTest(t, impl)
+}
+
+func TestSrotm(t *testing.T) {
+	testblas.SrotmTest(t, impl)
+}
+
+func TestSscal(t *testing.T) {
+	testblas.SscalTest(t, impl)
+}
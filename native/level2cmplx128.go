@@ -0,0 +1,1799 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math/cmplx"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/internal/asm/c128"
+)
+
+var _ blas.Complex128Level2 = Implementation{}
+
+// Zgbmv computes
+//  y = alpha * A * x + beta * y if tA == blas.NoTrans
+//  y = alpha * A^T * x + beta * y if tA == blas.Trans
+//  y = alpha * A^H * x + beta * y if tA == blas.ConjTrans
+// where a is an m×n band matrix with kL subdiagonals and kU super-diagonals,
+// and m and n refer to the size of the full dense matrix it represents.
+// x and y are vectors, and alpha and beta are scalars.
+func (Implementation) Zgbmv(tA blas.Transpose, m, n, kL, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if kL < 0 {
+		panic(kLLT0)
+	}
+	if kU < 0 {
+		panic(kULT0)
+	}
+	if lda < kL+kU+1 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	// Set up indexes
+	lenX := m
+	lenY := n
+	if tA == blas.NoTrans {
+		lenX = n
+		lenY = m
+	}
+	if (incX > 0 && (lenX-1)*incX >= len(x)) || (incX < 0 && (1-lenX)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (lenY-1)*incY >= len(y)) || (incY < 0 && (1-lenY)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(m-1)+kL+kU+1 > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - lenX) * incX
+	}
+	if incY < 0 {
+		ky = (1 - lenY) * incY
+	}
+
+	// First form y := beta * y
+	if beta != 1 {
+		if incY > 0 {
+			Implementation{}.Zscal(lenY, beta, y, incY)
+		} else {
+			Implementation{}.Zscal(lenY, beta, y, -incY)
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	// The elements of row i of A that lie within the band are
+	// stored in a[i*lda+kL-i+j] for max(0, i-kL) <= j < min(n, i+kU+1).
+	nRow := min(m, n+kL)
+	nCol := kL + 1 + kU
+	switch tA {
+	case blas.NoTrans:
+		iy := ky
+		for i := 0; i < nRow; i++ {
+			l := max(0, kL-i)
+			u := min(nCol, n+kL-i)
+			off := max(0, i-kL)
+			atmp := a[i*lda+l : i*lda+u]
+			var sum complex128
+			if incX == 1 {
+				sum = c128.DotuUnitary(atmp, x[off:off+u-l])
+			} else {
+				sum = c128.DotuInc(atmp, x, uintptr(u-l), 1, uintptr(incX), 0, uintptr(kx+off*incX))
+			}
+			y[iy] += alpha * sum
+			iy += incY
+		}
+	case blas.Trans:
+		ix := kx
+		for i := 0; i < nRow; i++ {
+			l := max(0, kL-i)
+			u := min(nCol, n+kL-i)
+			off := max(0, i-kL)
+			atmp := a[i*lda+l : i*lda+u]
+			tmp := alpha * x[ix]
+			if tmp != 0 {
+				if incY == 1 {
+					c128.AxpyUnitary(tmp, atmp, y[off:off+u-l])
+				} else {
+					c128.AxpyInc(tmp, atmp, y, uintptr(u-l), 1, uintptr(incY), 0, uintptr(ky+off*incY))
+				}
+			}
+			ix += incX
+		}
+	case blas.ConjTrans:
+		ix := kx
+		for i := 0; i < nRow; i++ {
+			l := max(0, kL-i)
+			u := min(nCol, n+kL-i)
+			off := max(0, i-kL)
+			atmp := a[i*lda+l : i*lda+u]
+			tmp := alpha * x[ix]
+			if tmp != 0 {
+				jy := ky + off*incY
+				for _, v := range atmp {
+					y[jy] += tmp * cmplx.Conj(v)
+					jy += incY
+				}
+			}
+			ix += incX
+		}
+	}
+}
+
+// Zgemv computes
+//  y = alpha * A * x + beta * y if tA = blas.NoTrans
+//  y = alpha * A^T * x + beta * y if tA = blas.Trans
+//  y = alpha * A^H * x + beta * y if tA = blas.ConjTrans
+// where A is an m×n dense matrix, x and y are vectors, and alpha and beta are
+// scalars.
+func (Implementation) Zgemv(tA blas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	// Set up indexes
+	lenX := m
+	lenY := n
+	if tA == blas.NoTrans {
+		lenX = n
+		lenY = m
+	}
+	if (incX > 0 && (lenX-1)*incX >= len(x)) || (incX < 0 && (1-lenX)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (lenY-1)*incY >= len(y)) || (incY < 0 && (1-lenY)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(m-1)+n > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - lenX) * incX
+	}
+	if incY < 0 {
+		ky = (1 - lenY) * incY
+	}
+
+	// First form y := beta * y
+	if beta != 1 {
+		if incY > 0 {
+			Implementation{}.Zscal(lenY, beta, y, incY)
+		} else {
+			Implementation{}.Zscal(lenY, beta, y, -incY)
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	switch tA {
+	case blas.NoTrans:
+		// Form y := alpha * A * x + y
+		if incX == 1 && incY == 1 {
+			for i := 0; i < m; i++ {
+				y[i] += alpha * c128.DotuUnitary(a[i*lda:i*lda+n], x[:n])
+			}
+			return
+		}
+		iy := ky
+		for i := 0; i < m; i++ {
+			y[iy] += alpha * c128.DotuInc(a[i*lda:i*lda+n], x, uintptr(n), 1, uintptr(incX), 0, uintptr(kx))
+			iy += incY
+		}
+	case blas.Trans:
+		// Form y := alpha * A^T * x + y
+		ix := kx
+		if incY == 1 {
+			for i := 0; i < m; i++ {
+				tmp := alpha * x[ix]
+				if tmp != 0 {
+					c128.AxpyUnitary(tmp, a[i*lda:i*lda+n], y[:n])
+				}
+				ix += incX
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			tmp := alpha * x[ix]
+			if tmp != 0 {
+				c128.AxpyInc(tmp, a[i*lda:i*lda+n], y, uintptr(n), 1, uintptr(incY), 0, uintptr(ky))
+			}
+			ix += incX
+		}
+	case blas.ConjTrans:
+		// Form y := alpha * A^H * x + y
+		ix := kx
+		if incY == 1 {
+			for i := 0; i < m; i++ {
+				tmp := alpha * x[ix]
+				if tmp != 0 {
+					for j, v := range a[i*lda : i*lda+n] {
+						y[j] += tmp * cmplx.Conj(v)
+					}
+				}
+				ix += incX
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			tmp := alpha * x[ix]
+			if tmp != 0 {
+				jy := ky
+				for _, v := range a[i*lda : i*lda+n] {
+					y[jy] += tmp * cmplx.Conj(v)
+					jy += incY
+				}
+			}
+			ix += incX
+		}
+	}
+}
+
+// Zgerc performs the rank-one operation
+//  A += alpha * x * y^H
+// where A is an m×n dense matrix, alpha is a scalar, x is an m element vector,
+// and y is an n element vector.
+func (Implementation) Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	checkZger(m, n, x, incX, y, incY, a, lda)
+
+	// Quick return if possible
+	if m == 0 || n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - m) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+	ix := kx
+	for i := 0; i < m; i++ {
+		tmp := alpha * x[ix]
+		if tmp != 0 {
+			atmp := a[i*lda : i*lda+n]
+			if incY == 1 {
+				for j, v := range y[:n] {
+					atmp[j] += tmp * cmplx.Conj(v)
+				}
+			} else {
+				jy := ky
+				for j := range atmp {
+					atmp[j] += tmp * cmplx.Conj(y[jy])
+					jy += incY
+				}
+			}
+		}
+		ix += incX
+	}
+}
+
+// Zgeru performs the rank-one operation
+//  A += alpha * x * y^T
+// where A is an m×n dense matrix, alpha is a scalar, x is an m element vector,
+// and y is an n element vector.
+func (Implementation) Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	checkZger(m, n, x, incX, y, incY, a, lda)
+
+	// Quick return if possible
+	if m == 0 || n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - m) * incX
+	}
+	if incY == 1 {
+		ix := kx
+		for i := 0; i < m; i++ {
+			tmp := alpha * x[ix]
+			if tmp != 0 {
+				c128.AxpyUnitary(tmp, y[:n], a[i*lda:i*lda+n])
+			}
+			ix += incX
+		}
+		return
+	}
+	var ky int
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+	ix := kx
+	for i := 0; i < m; i++ {
+		tmp := alpha * x[ix]
+		if tmp != 0 {
+			c128.AxpyInc(tmp, y, a[i*lda:i*lda+n], uintptr(n), uintptr(incY), 1, uintptr(ky), 0)
+		}
+		ix += incX
+	}
+}
+
+// Zhbmv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian band matrix with k super-diagonals. The imaginary parts of
+// the diagonal elements of A are ignored and assumed to be zero.
+func (Implementation) Zhbmv(ul blas.Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda < k+1 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(n-1)+k+1 > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+
+	// Form y = beta * y
+	if beta != 1 {
+		if incY > 0 {
+			Implementation{}.Zscal(n, beta, y, incY)
+		} else {
+			Implementation{}.Zscal(n, beta, y, -incY)
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		// Row i of the upper band holds A[i][i:min(n,i+k+1)] starting
+		// with the diagonal element.
+		for i := 0; i < n; i++ {
+			atmp := a[i*lda : i*lda+min(k+1, n-i)]
+			tmp1 := alpha * x[ix]
+			var tmp2 complex128
+			y[iy] += tmp1 * complex(real(atmp[0]), 0)
+			jx := ix + incX
+			jy := iy + incY
+			for _, v := range atmp[1:] {
+				y[jy] += tmp1 * cmplx.Conj(v)
+				tmp2 += v * x[jx]
+				jx += incX
+				jy += incY
+			}
+			y[iy] += alpha * tmp2
+			ix += incX
+			iy += incY
+		}
+		return
+	}
+	// Row i of the lower band holds A[i][max(0,i-k):i+1] ending
+	// with the diagonal element.
+	for i := 0; i < n; i++ {
+		l := max(0, k-i)
+		atmp := a[i*lda+l : i*lda+k]
+		tmp1 := alpha * x[ix]
+		var tmp2 complex128
+		jx := kx + (i-k+l)*incX
+		jy := ky + (i-k+l)*incY
+		for _, v := range atmp {
+			y[jy] += tmp1 * cmplx.Conj(v)
+			tmp2 += v * x[jx]
+			jx += incX
+			jy += incY
+		}
+		y[iy] += tmp1*complex(real(a[i*lda+k]), 0) + alpha*tmp2
+		ix += incX
+		iy += incY
+	}
+}
+
+// Zhemv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian matrix. The imaginary parts of the diagonal elements of A are
+// ignored and assumed to be zero.
+func (Implementation) Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(n-1)+n > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+
+	// Form y = beta * y
+	if beta != 1 {
+		if incY > 0 {
+			Implementation{}.Zscal(n, beta, y, incY)
+		} else {
+			Implementation{}.Zscal(n, beta, y, -incY)
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			tmp1 := alpha * x[ix]
+			var tmp2 complex128
+			y[iy] += tmp1 * complex(real(a[i*lda+i]), 0)
+			jx := ix + incX
+			jy := iy + incY
+			for _, v := range a[i*lda+i+1 : i*lda+n] {
+				y[jy] += tmp1 * cmplx.Conj(v)
+				tmp2 += v * x[jx]
+				jx += incX
+				jy += incY
+			}
+			y[iy] += alpha * tmp2
+			ix += incX
+			iy += incY
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		tmp1 := alpha * x[ix]
+		var tmp2 complex128
+		jx := kx
+		jy := ky
+		for _, v := range a[i*lda : i*lda+i] {
+			y[jy] += tmp1 * cmplx.Conj(v)
+			tmp2 += v * x[jx]
+			jx += incX
+			jy += incY
+		}
+		y[iy] += tmp1*complex(real(a[i*lda+i]), 0) + alpha*tmp2
+		ix += incX
+		iy += incY
+	}
+}
+
+// Zher performs the Hermitian rank-one operation
+//  A += alpha * x * x^H
+// where A is an n×n Hermitian matrix, alpha is a real scalar, and x is an n
+// element vector. On return, the imaginary parts of the diagonal elements of
+// A are set to zero.
+func (Implementation) Zher(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if lda*(n-1)+n > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	ix := kx
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			xi := x[ix]
+			if xi != 0 {
+				tmp := complex(alpha, 0) * xi
+				a[i*lda+i] = complex(real(a[i*lda+i])+real(tmp*cmplx.Conj(xi)), 0)
+				jx := ix + incX
+				atmp := a[i*lda+i+1 : i*lda+n]
+				for j := range atmp {
+					atmp[j] += tmp * cmplx.Conj(x[jx])
+					jx += incX
+				}
+			} else {
+				a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+			}
+			ix += incX
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		xi := x[ix]
+		if xi != 0 {
+			tmp := complex(alpha, 0) * xi
+			jx := kx
+			atmp := a[i*lda : i*lda+i]
+			for j := range atmp {
+				atmp[j] += tmp * cmplx.Conj(x[jx])
+				jx += incX
+			}
+			a[i*lda+i] = complex(real(a[i*lda+i])+real(tmp*cmplx.Conj(xi)), 0)
+		} else {
+			a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+		}
+		ix += incX
+	}
+}
+
+// Zher2 performs the Hermitian rank-two operation
+//  A += alpha * x * y^H + conj(alpha) * y * x^H
+// where alpha is a scalar, x and y are n element vectors and A is an n×n
+// Hermitian matrix. On return, the imaginary parts of the diagonal elements
+// of A are set to zero.
+func (Implementation) Zher2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(n-1)+n > len(a) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			if x[ix] != 0 || y[iy] != 0 {
+				tmp1 := alpha * x[ix]
+				tmp2 := cmplx.Conj(alpha) * y[iy]
+				aii := real(a[i*lda+i]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
+				a[i*lda+i] = complex(aii, 0)
+				jx := ix + incX
+				jy := iy + incY
+				atmp := a[i*lda+i+1 : i*lda+n]
+				for j := range atmp {
+					atmp[j] += tmp1*cmplx.Conj(y[jy]) + tmp2*cmplx.Conj(x[jx])
+					jx += incX
+					jy += incY
+				}
+			} else {
+				a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+			}
+			ix += incX
+			iy += incY
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		if x[ix] != 0 || y[iy] != 0 {
+			tmp1 := alpha * x[ix]
+			tmp2 := cmplx.Conj(alpha) * y[iy]
+			jx := kx
+			jy := ky
+			atmp := a[i*lda : i*lda+i]
+			for j := range atmp {
+				atmp[j] += tmp1*cmplx.Conj(y[jy]) + tmp2*cmplx.Conj(x[jx])
+				jx += incX
+				jy += incY
+			}
+			aii := real(a[i*lda+i]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
+			a[i*lda+i] = complex(aii, 0)
+		} else {
+			a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+		}
+		ix += incX
+		iy += incY
+	}
+}
+
+// Zhpmv performs the matrix-vector operation
+//  y = alpha * A * x + beta * y
+// where alpha and beta are scalars, x and y are vectors, and A is an n×n
+// Hermitian matrix in packed form. The imaginary parts of the diagonal
+// elements of A are ignored and assumed to be zero.
+func (Implementation) Zhpmv(ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+
+	// Form y = beta * y
+	if beta != 1 {
+		if incY > 0 {
+			Implementation{}.Zscal(n, beta, y, incY)
+		} else {
+			Implementation{}.Zscal(n, beta, y, -incY)
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	var offset int // Offset is the index of (i,i).
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			tmp1 := alpha * x[ix]
+			var tmp2 complex128
+			y[iy] += tmp1 * complex(real(ap[offset]), 0)
+			jx := ix + incX
+			jy := iy + incY
+			for _, v := range ap[offset+1 : offset+n-i] {
+				y[jy] += tmp1 * cmplx.Conj(v)
+				tmp2 += v * x[jx]
+				jx += incX
+				jy += incY
+			}
+			y[iy] += alpha * tmp2
+			ix += incX
+			iy += incY
+			offset += n - i
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		tmp1 := alpha * x[ix]
+		var tmp2 complex128
+		jx := kx
+		jy := ky
+		for _, v := range ap[offset-i : offset] {
+			y[jy] += tmp1 * cmplx.Conj(v)
+			tmp2 += v * x[jx]
+			jx += incX
+			jy += incY
+		}
+		y[iy] += tmp1*complex(real(ap[offset]), 0) + alpha*tmp2
+		ix += incX
+		iy += incY
+		offset += i + 2
+	}
+}
+
+// Zhpr performs the Hermitian rank-one operation
+//  A += alpha * x * x^H
+// where alpha is a real scalar, x is a vector, and A is an n×n Hermitian matrix
+// in packed form. On return, the imaginary parts of the diagonal elements of A
+// are set to zero.
+func (Implementation) Zhpr(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	var offset int // Offset is the index of (i,i).
+	ix := kx
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			xi := x[ix]
+			if xi != 0 {
+				tmp := complex(alpha, 0) * xi
+				ap[offset] = complex(real(ap[offset])+real(tmp*cmplx.Conj(xi)), 0)
+				jx := ix + incX
+				atmp := ap[offset+1 : offset+n-i]
+				for j := range atmp {
+					atmp[j] += tmp * cmplx.Conj(x[jx])
+					jx += incX
+				}
+			} else {
+				ap[offset] = complex(real(ap[offset]), 0)
+			}
+			ix += incX
+			offset += n - i
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		xi := x[ix]
+		if xi != 0 {
+			tmp := complex(alpha, 0) * xi
+			jx := kx
+			atmp := ap[offset-i : offset]
+			for j := range atmp {
+				atmp[j] += tmp * cmplx.Conj(x[jx])
+				jx += incX
+			}
+			ap[offset] = complex(real(ap[offset])+real(tmp*cmplx.Conj(xi)), 0)
+		} else {
+			ap[offset] = complex(real(ap[offset]), 0)
+		}
+		ix += incX
+		offset += i + 2
+	}
+}
+
+// Zhpr2 performs the Hermitian rank-2 operation
+//  A += alpha * x * y^H + conj(alpha) * y * x^H
+// where alpha is a complex scalar, x and y are n element vectors, and A is an
+// n×n Hermitian matrix, supplied in packed form. On return, the imaginary parts
+// of the diagonal elements of A are set to zero.
+func (Implementation) Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || alpha == 0 {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	if incY < 0 {
+		ky = (1 - n) * incY
+	}
+	var offset int // Offset is the index of (i,i).
+	ix := kx
+	iy := ky
+	if ul == blas.Upper {
+		for i := 0; i < n; i++ {
+			if x[ix] != 0 || y[iy] != 0 {
+				tmp1 := alpha * x[ix]
+				tmp2 := cmplx.Conj(alpha) * y[iy]
+				aii := real(ap[offset]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
+				ap[offset] = complex(aii, 0)
+				jx := ix + incX
+				jy := iy + incY
+				atmp := ap[offset+1 : offset+n-i]
+				for j := range atmp {
+					atmp[j] += tmp1*cmplx.Conj(y[jy]) + tmp2*cmplx.Conj(x[jx])
+					jx += incX
+					jy += incY
+				}
+			} else {
+				ap[offset] = complex(real(ap[offset]), 0)
+			}
+			ix += incX
+			iy += incY
+			offset += n - i
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		if x[ix] != 0 || y[iy] != 0 {
+			tmp1 := alpha * x[ix]
+			tmp2 := cmplx.Conj(alpha) * y[iy]
+			jx := kx
+			jy := ky
+			atmp := ap[offset-i : offset]
+			for j := range atmp {
+				atmp[j] += tmp1*cmplx.Conj(y[jy]) + tmp2*cmplx.Conj(x[jx])
+				jx += incX
+				jy += incY
+			}
+			aii := real(ap[offset]) + real(tmp1*cmplx.Conj(y[iy])) + real(tmp2*cmplx.Conj(x[ix]))
+			ap[offset] = complex(aii, 0)
+		} else {
+			ap[offset] = complex(real(ap[offset]), 0)
+		}
+		ix += incX
+		iy += incY
+		offset += i + 2
+	}
+}
+
+// checkZger checks the parameters shared by Zgerc and Zgeru and panics
+// if any of them are invalid.
+func checkZger(m, n int, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (m-1)*incX >= len(x)) || (incX < 0 && (1-m)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(m-1)+n > len(a) {
+		panic(badLdA)
+	}
+}
+
+// Ztbmv performs one of the matrix-vector operations
+//  x = A * x    if tA == blas.NoTrans
+//  x = A^T * x  if tA == blas.Trans
+//  x = A^H * x  if tA == blas.ConjTrans
+// where x is an n element vector and A is an n×n triangular band matrix, with
+// (k+1) diagonals.
+func (Implementation) Ztbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda*(n-1)+k+1 > len(a) || lda < k+1 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			ix := kx
+			for i := 0; i < n; i++ {
+				atmp := a[i*lda : i*lda+min(k+1, n-i)]
+				var tmp complex128
+				if nonUnit {
+					tmp = atmp[0] * x[ix]
+				} else {
+					tmp = x[ix]
+				}
+				jx := ix + incX
+				for _, v := range atmp[1:] {
+					tmp += v * x[jx]
+					jx += incX
+				}
+				x[ix] = tmp
+				ix += incX
+			}
+			return
+		}
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			l := max(0, k-i)
+			atmp := a[i*lda+l : i*lda+k]
+			var tmp complex128
+			if nonUnit {
+				tmp = a[i*lda+k] * x[ix]
+			} else {
+				tmp = x[ix]
+			}
+			jx := kx + (i-k+l)*incX
+			for _, v := range atmp {
+				tmp += v * x[jx]
+				jx += incX
+			}
+			x[ix] = tmp
+			ix -= incX
+		}
+		return
+	}
+
+	noConj := tA == blas.Trans
+	if ul == blas.Upper {
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			atmp := a[i*lda : i*lda+min(k+1, n-i)]
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range atmp[1:] {
+				if !noConj {
+					v = cmplx.Conj(v)
+				}
+				x[jx] += v * xi
+				jx += incX
+			}
+			if nonUnit {
+				if noConj {
+					x[ix] *= atmp[0]
+				} else {
+					x[ix] *= cmplx.Conj(atmp[0])
+				}
+			}
+			ix -= incX
+		}
+		return
+	}
+	ix := kx
+	for i := 0; i < n; i++ {
+		l := max(0, k-i)
+		atmp := a[i*lda+l : i*lda+k]
+		xi := x[ix]
+		jx := kx + (i-k+l)*incX
+		for _, v := range atmp {
+			if !noConj {
+				v = cmplx.Conj(v)
+			}
+			x[jx] += v * xi
+			jx += incX
+		}
+		if nonUnit {
+			if noConj {
+				x[ix] *= a[i*lda+k]
+			} else {
+				x[ix] *= cmplx.Conj(a[i*lda+k])
+			}
+		}
+		ix += incX
+	}
+}
+
+// Ztbsv solves one of the systems of equations
+//  A * x = b    if tA == blas.NoTrans
+//  A^T * x = b  if tA == blas.Trans
+//  A^H * x = b  if tA == blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular band matrix
+// with (k+1) diagonals.
+//
+// On entry, x contains the values of b, and the solution is
+// stored in-place into x.
+//
+// No test for singularity or near-singularity is included in this
+// routine. Such tests must be performed before calling this routine.
+func (Implementation) Ztbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if lda*(n-1)+k+1 > len(a) || lda < k+1 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				atmp := a[i*lda : i*lda+min(k+1, n-i)]
+				var sum complex128
+				jx := ix + incX
+				for _, v := range atmp[1:] {
+					sum += v * x[jx]
+					jx += incX
+				}
+				x[ix] -= sum
+				if nonUnit {
+					x[ix] /= atmp[0]
+				}
+				ix -= incX
+			}
+			return
+		}
+		ix := kx
+		for i := 0; i < n; i++ {
+			l := max(0, k-i)
+			atmp := a[i*lda+l : i*lda+k]
+			var sum complex128
+			jx := kx + (i-k+l)*incX
+			for _, v := range atmp {
+				sum += v * x[jx]
+				jx += incX
+			}
+			x[ix] -= sum
+			if nonUnit {
+				x[ix] /= a[i*lda+k]
+			}
+			ix += incX
+		}
+		return
+	}
+
+	noConj := tA == blas.Trans
+	if ul == blas.Upper {
+		ix := kx
+		for i := 0; i < n; i++ {
+			atmp := a[i*lda : i*lda+min(k+1, n-i)]
+			if nonUnit {
+				if noConj {
+					x[ix] /= atmp[0]
+				} else {
+					x[ix] /= cmplx.Conj(atmp[0])
+				}
+			}
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range atmp[1:] {
+				if !noConj {
+					v = cmplx.Conj(v)
+				}
+				x[jx] -= v * xi
+				jx += incX
+			}
+			ix += incX
+		}
+		return
+	}
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if nonUnit {
+			if noConj {
+				x[ix] /= a[i*lda+k]
+			} else {
+				x[ix] /= cmplx.Conj(a[i*lda+k])
+			}
+		}
+		l := max(0, k-i)
+		atmp := a[i*lda+l : i*lda+k]
+		xi := x[ix]
+		jx := kx + (i-k+l)*incX
+		for _, v := range atmp {
+			if !noConj {
+				v = cmplx.Conj(v)
+			}
+			x[jx] -= v * xi
+			jx += incX
+		}
+		ix -= incX
+	}
+}
+
+// Ztpmv performs one of the matrix-vector operations
+//  x = A * x    if tA == blas.NoTrans
+//  x = A^T * x  if tA == blas.Trans
+//  x = A^H * x  if tA == blas.ConjTrans
+// where x is an n element vector and A is an n×n triangular matrix, supplied in
+// packed form.
+func (Implementation) Ztpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			// Offset is the index of (i,i).
+			offset := 0
+			ix := kx
+			for i := 0; i < n; i++ {
+				var tmp complex128
+				if nonUnit {
+					tmp = ap[offset] * x[ix]
+				} else {
+					tmp = x[ix]
+				}
+				jx := ix + incX
+				for _, v := range ap[offset+1 : offset+n-i] {
+					tmp += v * x[jx]
+					jx += incX
+				}
+				x[ix] = tmp
+				ix += incX
+				offset += n - i
+			}
+			return
+		}
+		// Offset is the index of (i,i).
+		offset := n*(n+1)/2 - 1
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			var tmp complex128
+			if nonUnit {
+				tmp = ap[offset] * x[ix]
+			} else {
+				tmp = x[ix]
+			}
+			jx := kx
+			for _, v := range ap[offset-i : offset] {
+				tmp += v * x[jx]
+				jx += incX
+			}
+			x[ix] = tmp
+			ix -= incX
+			offset -= i + 1
+		}
+		return
+	}
+
+	noConj := tA == blas.Trans
+	if ul == blas.Upper {
+		// Offset is the index of (i,i).
+		offset := n*(n+1)/2 - 1
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range ap[offset+1 : offset+n-i] {
+				if !noConj {
+					v = cmplx.Conj(v)
+				}
+				x[jx] += v * xi
+				jx += incX
+			}
+			if nonUnit {
+				if noConj {
+					x[ix] *= ap[offset]
+				} else {
+					x[ix] *= cmplx.Conj(ap[offset])
+				}
+			}
+			ix -= incX
+			offset -= n - i + 1
+		}
+		return
+	}
+	// Offset is the index of (i,i).
+	offset := 0
+	ix := kx
+	for i := 0; i < n; i++ {
+		xi := x[ix]
+		jx := kx
+		for _, v := range ap[offset-i : offset] {
+			if !noConj {
+				v = cmplx.Conj(v)
+			}
+			x[jx] += v * xi
+			jx += incX
+		}
+		if nonUnit {
+			if noConj {
+				x[ix] *= ap[offset]
+			} else {
+				x[ix] *= cmplx.Conj(ap[offset])
+			}
+		}
+		ix += incX
+		offset += i + 2
+	}
+}
+
+// Ztpsv solves one of the systems of equations
+//  A * x = b    if tA == blas.NoTrans
+//  A^T * x = b  if tA == blas.Trans
+//  A^H * x = b  if tA == blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular matrix in
+// packed form.
+//
+// On entry, x contains the values of b, and the solution is
+// stored in-place into x.
+//
+// No test for singularity or near-singularity is included in this
+// routine. Such tests must be performed before calling this routine.
+func (Implementation) Ztpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(ap) < n*(n+1)/2 {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			// Offset is the index of (i,i).
+			offset := n*(n+1)/2 - 1
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				var sum complex128
+				jx := ix + incX
+				for _, v := range ap[offset+1 : offset+n-i] {
+					sum += v * x[jx]
+					jx += incX
+				}
+				x[ix] -= sum
+				if nonUnit {
+					x[ix] /= ap[offset]
+				}
+				ix -= incX
+				offset -= n - i + 1
+			}
+			return
+		}
+		// Offset is the index of (i,i).
+		offset := 0
+		ix := kx
+		for i := 0; i < n; i++ {
+			var sum complex128
+			jx := kx
+			for _, v := range ap[offset-i : offset] {
+				sum += v * x[jx]
+				jx += incX
+			}
+			x[ix] -= sum
+			if nonUnit {
+				x[ix] /= ap[offset]
+			}
+			ix += incX
+			offset += i + 2
+		}
+		return
+	}
+
+	noConj := tA == blas.Trans
+	if ul == blas.Upper {
+		// Offset is the index of (i,i).
+		offset := 0
+		ix := kx
+		for i := 0; i < n; i++ {
+			if nonUnit {
+				if noConj {
+					x[ix] /= ap[offset]
+				} else {
+					x[ix] /= cmplx.Conj(ap[offset])
+				}
+			}
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range ap[offset+1 : offset+n-i] {
+				if !noConj {
+					v = cmplx.Conj(v)
+				}
+				x[jx] -= v * xi
+				jx += incX
+			}
+			ix += incX
+			offset += n - i
+		}
+		return
+	}
+	// Offset is the index of (i,i).
+	offset := n*(n+1)/2 - 1
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if nonUnit {
+			if noConj {
+				x[ix] /= ap[offset]
+			} else {
+				x[ix] /= cmplx.Conj(ap[offset])
+			}
+		}
+		xi := x[ix]
+		jx := kx
+		for _, v := range ap[offset-i : offset] {
+			if !noConj {
+				v = cmplx.Conj(v)
+			}
+			x[jx] -= v * xi
+			jx += incX
+		}
+		ix -= incX
+		offset -= i + 1
+	}
+}
+
+// Ztrmv performs one of the matrix-vector operations
+//  x = A * x    if tA == blas.NoTrans
+//  x = A^T * x  if tA == blas.Trans
+//  x = A^H * x  if tA == blas.ConjTrans
+// where x is a vector, and A is an n×n triangular matrix.
+func (Implementation) Ztrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if lda*(n-1)+n > len(a) || lda < max(1, n) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			ix := kx
+			for i := 0; i < n; i++ {
+				var tmp complex128
+				if nonUnit {
+					tmp = a[i*lda+i] * x[ix]
+				} else {
+					tmp = x[ix]
+				}
+				x[ix] = tmp + c128.DotuInc(a[i*lda+i+1:i*lda+n], x, uintptr(n-i-1), 1, uintptr(incX), 0, uintptr(ix+incX))
+				ix += incX
+			}
+			return
+		}
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			var tmp complex128
+			if nonUnit {
+				tmp = a[i*lda+i] * x[ix]
+			} else {
+				tmp = x[ix]
+			}
+			x[ix] = tmp + c128.DotuInc(a[i*lda:i*lda+i], x, uintptr(i), 1, uintptr(incX), 0, uintptr(kx))
+			ix -= incX
+		}
+		return
+	}
+
+	if tA == blas.Trans {
+		if ul == blas.Upper {
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				c128.AxpyInc(x[ix], a[i*lda+i+1:i*lda+n], x, uintptr(n-i-1), 1, uintptr(incX), 0, uintptr(ix+incX))
+				if nonUnit {
+					x[ix] *= a[i*lda+i]
+				}
+				ix -= incX
+			}
+			return
+		}
+		ix := kx
+		for i := 0; i < n; i++ {
+			c128.AxpyInc(x[ix], a[i*lda:i*lda+i], x, uintptr(i), 1, uintptr(incX), 0, uintptr(kx))
+			if nonUnit {
+				x[ix] *= a[i*lda+i]
+			}
+			ix += incX
+		}
+		return
+	}
+
+	// Cases where A is conjugate transposed.
+	if ul == blas.Upper {
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range a[i*lda+i+1 : i*lda+n] {
+				x[jx] += cmplx.Conj(v) * xi
+				jx += incX
+			}
+			if nonUnit {
+				x[ix] *= cmplx.Conj(a[i*lda+i])
+			}
+			ix -= incX
+		}
+		return
+	}
+	ix := kx
+	for i := 0; i < n; i++ {
+		xi := x[ix]
+		jx := kx
+		for _, v := range a[i*lda : i*lda+i] {
+			x[jx] += cmplx.Conj(v) * xi
+			jx += incX
+		}
+		if nonUnit {
+			x[ix] *= cmplx.Conj(a[i*lda+i])
+		}
+		ix += incX
+	}
+}
+
+// Ztrsv solves one of the systems of equations
+//  A * x = b    if tA == blas.NoTrans
+//  A^T * x = b  if tA == blas.Trans
+//  A^H * x = b  if tA == blas.ConjTrans
+// where b and x are n element vectors and A is an n×n triangular matrix.
+//
+// On entry, x contains the values of b, and the solution is
+// stored in-place into x.
+//
+// No test for singularity or near-singularity is included in this
+// routine. Such tests must be performed before calling this routine.
+func (Implementation) Ztrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda*(n-1)+n > len(a) || lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+
+	// Quick return if possible
+	if n == 0 {
+		return
+	}
+
+	var kx int
+	if incX < 0 {
+		kx = (1 - n) * incX
+	}
+	nonUnit := d == blas.NonUnit
+
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			ix := kx + (n-1)*incX
+			for i := n - 1; i >= 0; i-- {
+				x[ix] -= c128.DotuInc(a[i*lda+i+1:i*lda+n], x, uintptr(n-i-1), 1, uintptr(incX), 0, uintptr(ix+incX))
+				if nonUnit {
+					x[ix] /= a[i*lda+i]
+				}
+				ix -= incX
+			}
+			return
+		}
+		ix := kx
+		for i := 0; i < n; i++ {
+			x[ix] -= c128.DotuInc(a[i*lda:i*lda+i], x, uintptr(i), 1, uintptr(incX), 0, uintptr(kx))
+			if nonUnit {
+				x[ix] /= a[i*lda+i]
+			}
+			ix += incX
+		}
+		return
+	}
+
+	if tA == blas.Trans {
+		if ul == blas.Upper {
+			ix := kx
+			for i := 0; i < n; i++ {
+				if nonUnit {
+					x[ix] /= a[i*lda+i]
+				}
+				c128.AxpyInc(-x[ix], a[i*lda+i+1:i*lda+n], x, uintptr(n-i-1), 1, uintptr(incX), 0, uintptr(ix+incX))
+				ix += incX
+			}
+			return
+		}
+		ix := kx + (n-1)*incX
+		for i := n - 1; i >= 0; i-- {
+			if nonUnit {
+				x[ix] /= a[i*lda+i]
+			}
+			c128.AxpyInc(-x[ix], a[i*lda:i*lda+i], x, uintptr(i), 1, uintptr(incX), 0, uintptr(kx))
+			ix -= incX
+		}
+		return
+	}
+
+	// Cases where A is conjugate transposed.
+	if ul == blas.Upper {
+		ix := kx
+		for i := 0; i < n; i++ {
+			if nonUnit {
+				x[ix] /= cmplx.Conj(a[i*lda+i])
+			}
+			xi := x[ix]
+			jx := ix + incX
+			for _, v := range a[i*lda+i+1 : i*lda+n] {
+				x[jx] -= cmplx.Conj(v) * xi
+				jx += incX
+			}
+			ix += incX
+		}
+		return
+	}
+	ix := kx + (n-1)*incX
+	for i := n - 1; i >= 0; i-- {
+		if nonUnit {
+			x[ix] /= cmplx.Conj(a[i*lda+i])
+		}
+		xi := x[ix]
+		jx := kx
+		for _, v := range a[i*lda : i*lda+i] {
+			x[jx] -= cmplx.Conj(v) * xi
+			jx += incX
+		}
+		ix -= incX
+	}
+}
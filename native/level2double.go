@@ -15,7 +15,7 @@ var _ blas.Float64Level2 = Implementation{}
 //  y = alpha * a * x + beta * y if tA = blas.NoTrans
 //  y = alpha * A^T * x + beta * y if tA = blas.Trans or blas.ConjTrans
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
-func (Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+func (impl Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
 		panic(badTranspose)
 	}
@@ -79,28 +79,208 @@ func (Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []floa
 	if alpha == 0 {
 		return
 	}
+	if impl.Reproducible {
+		dgemvReproducible(impl, tA, m, n, alpha, a, lda, x, incX, kx, y, incY, ky)
+		return
+	}
 
 	// Form y := alpha * A * x + y
+	nWorkers := impl.level2Workers(m * n)
 	if tA == blas.NoTrans {
-		if incX == 1 && incY == 1 {
-			for i := 0; i < m; i++ {
-				y[i] += alpha * f64.DotUnitary(a[lda*i:lda*i+n], x)
-			}
+		if nWorkers == 1 {
+			dgemvRows(0, m, n, alpha, a, lda, x, incX, kx, y, incY, ky)
 			return
 		}
-		iy := ky
-		for i := 0; i < m; i++ {
-			y[iy] += alpha * f64.DotInc(x, a[lda*i:lda*i+n], uintptr(n), uintptr(incX), 1, uintptr(kx), 0)
-			iy += incY
-		}
+		// The rows of A are split between the workers, so each element
+		// of y is computed exactly as in the serial case.
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			dgemvRows(i0, i1, n, alpha, a, lda, x, incX, kx, y, incY, ky)
+		})
 		return
 	}
 	// Cases where a is transposed.
+	if nWorkers == 1 {
+		dgemvCols(0, n, m, alpha, a, lda, x, incX, kx, y, incY, ky)
+		return
+	}
+	// The columns of A are split between the workers, each of which
+	// accumulates the sums for its own part of y.
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		dgemvCols(j0, j1, m, alpha, a, lda, x, incX, kx, y, incY, ky)
+	})
+}
+
+// DgemvBatched computes
+//  y[i] = alpha * A[i] * x[i] + beta * y[i]    if tA = blas.NoTrans
+//  y[i] = alpha * A[i]^T * x[i] + beta * y[i]  if tA = blas.Trans or blas.ConjTrans
+// for each product i in the batch, where the A[i] are m×n dense matrices held
+// in a[i], the x[i] and y[i] are vectors, and alpha and beta are scalars. All
+// of the matrices and vectors of the batch have the sizes, strides and
+// increments of a single call to Dgemv. a, x and y must have the same
+// length, and the y[i] must not overlap.
+//
+// The products are shared between the workers of impl, and each of them is
+// computed on a single goroutine.
+func (impl Implementation) DgemvBatched(tA blas.Transpose, m, n int, alpha float64, a [][]float64, lda int, x [][]float64, incX int, beta float64, y [][]float64, incY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if len(a) != len(y) || len(x) != len(y) {
+		panic(badBatchLen)
+	}
+	lenX := m
+	lenY := n
+	if tA == blas.NoTrans {
+		lenX = n
+		lenY = m
+	}
+	for i := range y {
+		if (lenX-1)*abs(incX) >= len(x[i]) {
+			panic(batchEntry(badX, i))
+		}
+		if (lenY-1)*abs(incY) >= len(y[i]) {
+			panic(batchEntry(badY, i))
+		}
+		if lda*(m-1)+n > len(a[i]) {
+			panic(batchEntry(badLdA, i))
+		}
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || len(y) == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	// Each product is computed serially with the other settings of impl.
+	serial := impl
+	serial.Serial = true
+	nWorkers := impl.batchWorkers(len(y) * m * n)
+	parallelRange(nWorkers, len(y), func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			serial.Dgemv(tA, m, n, alpha, a[i], lda, x[i], incX, beta, y[i], incY)
+		}
+	})
+}
+
+// DgemvStridedBatched computes
+//  y[i] = alpha * A[i] * x[i] + beta * y[i]    if tA = blas.NoTrans
+//  y[i] = alpha * A[i]^T * x[i] + beta * y[i]  if tA = blas.Trans or blas.ConjTrans
+// for 0 <= i < batch, where the A[i] are m×n dense matrices starting at
+// a[i*strideA:], the x[i] and y[i] are vectors starting at x[i*strideX:] and
+// y[i*strideY:], and alpha and beta are scalars. All of the matrices and
+// vectors of the batch have the sizes, strides and increments of a single
+// call to Dgemv. A zero strideA or strideX uses the same A or x for every
+// product. strideY must be large enough that the y[i] do not overlap.
+//
+// The products are shared between the workers of impl, and each of them is
+// computed on a single goroutine.
+func (impl Implementation) DgemvStridedBatched(tA blas.Transpose, m, n int, alpha float64, a []float64, lda, strideA int, x []float64, incX, strideX int, beta float64, y []float64, incY, strideY int, batch int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if batch < 0 {
+		panic(batchLT0)
+	}
+	if strideA < 0 || strideX < 0 || strideY < 0 {
+		panic(badBatchStride)
+	}
+	if batch == 0 || m == 0 || n == 0 {
+		return
+	}
+	lenX := m
+	lenY := n
+	if tA == blas.NoTrans {
+		lenX = n
+		lenY = m
+	}
+	if (batch-1)*strideX+(lenX-1)*abs(incX) >= len(x) {
+		panic(batchEntry(badX, batch-1))
+	}
+	if (batch-1)*strideY+(lenY-1)*abs(incY) >= len(y) {
+		panic(batchEntry(badY, batch-1))
+	}
+	if (batch-1)*strideA+lda*(m-1)+n > len(a) {
+		panic(batchEntry(badLdA, batch-1))
+	}
+	if batch > 1 && strideY <= (lenY-1)*abs(incY) {
+		panic(badBatchOverlap)
+	}
+
+	// Quick return if possible
+	if alpha == 0 && beta == 1 {
+		return
+	}
+
+	// Each product is computed serially with the other settings of impl.
+	serial := impl
+	serial.Serial = true
+	nWorkers := impl.batchWorkers(batch * m * n)
+	parallelRange(nWorkers, batch, func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			serial.Dgemv(tA, m, n, alpha, a[i*strideA:], lda, x[i*strideX:], incX, beta, y[i*strideY:], incY)
+		}
+	})
+}
+
+// dgemvRows computes
+//  y[i] += alpha * A[i, :] * x
+// for the rows i0 <= i < i1 of the m×n matrix A. kx and ky are the indices
+// of the first elements of x and y.
+func dgemvRows(i0, i1, n int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
 	if incX == 1 && incY == 1 {
+		for i := i0; i < i1; i++ {
+			y[i] += alpha * f64.DotUnitary(a[lda*i:lda*i+n], x)
+		}
+		return
+	}
+	iy := ky + i0*incY
+	for i := i0; i < i1; i++ {
+		y[iy] += alpha * f64.DotInc(x, a[lda*i:lda*i+n], uintptr(n), uintptr(incX), 1, uintptr(kx), 0)
+		iy += incY
+	}
+}
+
+// dgemvCols computes
+//  y[j] += alpha * A[:, j]^T * x
+// for the columns j0 <= j < j1 of the m×n matrix A. kx and ky are the
+// indices of the first elements of x and y.
+func dgemvCols(j0, j1, m int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
+	if incX == 1 && incY == 1 {
+		yj := y[j0:j1]
 		for i := 0; i < m; i++ {
 			tmp := alpha * x[i]
 			if tmp != 0 {
-				f64.AxpyUnitaryTo(y, tmp, a[lda*i:lda*i+n], y)
+				f64.AxpyUnitaryTo(yj, tmp, a[lda*i+j0:lda*i+j1], yj)
 			}
 		}
 		return
@@ -109,7 +289,7 @@ func (Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []floa
 	for i := 0; i < m; i++ {
 		tmp := alpha * x[ix]
 		if tmp != 0 {
-			f64.AxpyInc(tmp, a[lda*i:lda*i+n], y, uintptr(n), 1, uintptr(incY), 0, uintptr(ky))
+			f64.AxpyInc(tmp, a[lda*i+j0:lda*i+j1], y, uintptr(j1-j0), 1, uintptr(incY), 0, uintptr(ky+j0*incY))
 		}
 		ix += incX
 	}
@@ -118,7 +298,7 @@ func (Implementation) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []floa
 // Dger performs the rank-one operation
 //  A += alpha * x * y^T
 // where A is an m×n dense matrix, x and y are vectors, and alpha is a scalar.
-func (Implementation) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+func (impl Implementation) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	// Check inputs
 	if m < 0 {
 		panic("m < 0")
@@ -163,11 +343,25 @@ func (Implementation) Dger(m, n int, alpha float64, x []float64, incX int, y []f
 		kx = -(m - 1) * incX
 	}
 
+	if nWorkers := impl.level2Workers(m * n); nWorkers > 1 {
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			dgerRows(i0, i1, m, n, alpha, x, incX, kx, y, incY, ky, a, lda)
+		})
+		return
+	}
+	dgerRows(0, m, m, n, alpha, x, incX, kx, y, incY, ky, a, lda)
+}
+
+// dgerRows performs the rank-one update
+//  A[i, :] += alpha * x[i] * y^T
+// for the rows i0 <= i < i1 of the m×n matrix A. kx and ky are the indices
+// of the first elements of x and y.
+func dgerRows(i0, i1, m, n int, alpha float64, x []float64, incX, kx int, y []float64, incY, ky int, a []float64, lda int) {
 	if incX == 1 && incY == 1 {
 		x = x[:m]
 		y = y[:n]
-		for i, xv := range x {
-			tmp := alpha * xv
+		for i := i0; i < i1; i++ {
+			tmp := alpha * x[i]
 			if tmp != 0 {
 				atmp := a[i*lda : i*lda+n]
 				f64.AxpyUnitaryTo(atmp, tmp, y, atmp)
@@ -176,8 +370,8 @@ func (Implementation) Dger(m, n int, alpha float64, x []float64, incX int, y []f
 		return
 	}
 
-	ix := kx
-	for i := 0; i < m; i++ {
+	ix := kx + i0*incX
+	for i := i0; i < i1; i++ {
 		tmp := alpha * x[ix]
 		if tmp != 0 {
 			f64.AxpyInc(tmp, y, a[i*lda:i*lda+n], uintptr(n), uintptr(incY), 1, uintptr(ky), 0)
@@ -205,7 +399,7 @@ func (Implementation) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64,
 	if kL < 0 {
 		panic(kLLT0)
 	}
-	if kL < 0 {
+	if kU < 0 {
 		panic(kULT0)
 	}
 	if lda < kL+kU+1 {
@@ -264,14 +458,15 @@ func (Implementation) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64,
 
 	// i and j are indices of the compacted banded matrix.
 	// off is the offset into the dense matrix (off + j = densej)
-	ld := min(m, n)
+	// Rows i >= n+kL lie entirely outside the band.
+	nRow := min(m, n+kL)
 	nCol := kU + 1 + kL
 	if tA == blas.NoTrans {
 		iy := ky
 		if incX == 1 {
-			for i := 0; i < m; i++ {
+			for i := 0; i < nRow; i++ {
 				l := max(0, kL-i)
-				u := min(nCol, ld+kL-i)
+				u := min(nCol, n+kL-i)
 				off := max(0, i-kL)
 				atmp := a[i*lda+l : i*lda+u]
 				xtmp := x[off : off+u-l]
@@ -284,9 +479,9 @@ func (Implementation) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64,
 			}
 			return
 		}
-		for i := 0; i < m; i++ {
+		for i := 0; i < nRow; i++ {
 			l := max(0, kL-i)
-			u := min(nCol, ld+kL-i)
+			u := min(nCol, n+kL-i)
 			off := max(0, i-kL)
 			atmp := a[i*lda+l : i*lda+u]
 			jx := kx
@@ -301,9 +496,9 @@ func (Implementation) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64,
 		return
 	}
 	if incX == 1 {
-		for i := 0; i < m; i++ {
+		for i := 0; i < nRow; i++ {
 			l := max(0, kL-i)
-			u := min(nCol, ld+kL-i)
+			u := min(nCol, n+kL-i)
 			off := max(0, i-kL)
 			atmp := a[i*lda+l : i*lda+u]
 			tmp := alpha * x[i]
@@ -316,9 +511,9 @@ func (Implementation) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64,
 		return
 	}
 	ix := kx
-	for i := 0; i < m; i++ {
+	for i := 0; i < nRow; i++ {
 		l := max(0, kL-i)
-		u := min(nCol, ld+kL-i)
+		u := min(nCol, n+kL-i)
 		off := max(0, i-kL)
 		atmp := a[i*lda+l : i*lda+u]
 		tmp := alpha * x[ix]
@@ -658,7 +853,7 @@ func (Implementation) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int,
 //    y = alpha * A * x + beta * y,
 // where a is an n×n symmetric matrix, x and y are vectors, and alpha and
 // beta are scalars.
-func (Implementation) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+func (impl Implementation) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	// Check inputs
 	if ul != blas.Lower && ul != blas.Upper {
 		panic(badUplo)
@@ -720,6 +915,11 @@ func (Implementation) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda
 		return
 	}
 
+	if nWorkers := impl.level2Workers(n * n); nWorkers > 1 {
+		dsymvParallel(nWorkers, ul, n, alpha, a, lda, x, incX, kx, y, incY, ky)
+		return
+	}
+
 	if ul == blas.Upper {
 		if incX == 1 {
 			iy := ky
@@ -801,6 +1001,78 @@ func (Implementation) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda
 	}
 }
 
+// dsymvParallel computes
+//  y += alpha * A * x
+// where A is the n×n symmetric matrix stored in the ul triangle of a, using
+// up to nWorkers goroutines. The rows of the triangle are split into nWorkers
+// ranges holding about the same number of elements, and the products with
+// each range are accumulated in a separate buffer. The buffers are added to
+// y in order, so the result does not depend on the scheduling of the work.
+func dsymvParallel(nWorkers int, ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
+	if incX != 1 {
+		xc := make([]float64, n)
+		ix := kx
+		for i := range xc {
+			xc[i] = x[ix]
+			ix += incX
+		}
+		x = xc
+	}
+	x = x[:n]
+
+	bounds := make([]int, nWorkers+1)
+	bounds[nWorkers] = n
+	total := n * (n + 1) / 2
+	var work int
+	c := 1
+	for i := 0; i < n && c < nWorkers; i++ {
+		if ul == blas.Upper {
+			work += n - i
+		} else {
+			work += i + 1
+		}
+		for c < nWorkers && work*nWorkers >= c*total {
+			bounds[c] = i + 1
+			c++
+		}
+	}
+
+	buf := make([]float64, nWorkers*n)
+	parallelFor(nWorkers, nWorkers, func(c int) {
+		dsymvRows(ul, bounds[c], bounds[c+1], n, a, lda, x, buf[c*n:(c+1)*n])
+	})
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		jy := ky + j0*incY
+		for j := j0; j < j1; j++ {
+			var sum float64
+			for c := 0; c < nWorkers; c++ {
+				sum += buf[c*n+j]
+			}
+			y[jy] += alpha * sum
+			jy += incY
+		}
+	})
+}
+
+// dsymvRows adds to t the products of the rows i0 <= i < i1 of the ul
+// triangle of the n×n symmetric matrix A with x, and the products of the
+// corresponding columns of the other triangle with x.
+func dsymvRows(ul blas.Uplo, i0, i1, n int, a []float64, lda int, x, t []float64) {
+	if ul == blas.Upper {
+		for i := i0; i < i1; i++ {
+			atmp := a[i*lda+i+1 : i*lda+n]
+			t[i] += x[i]*a[i*lda+i] + f64.DotUnitary(atmp, x[i+1:])
+			f64.AxpyUnitaryTo(t[i+1:], x[i], atmp, t[i+1:])
+		}
+		return
+	}
+	for i := i0; i < i1; i++ {
+		atmp := a[i*lda : i*lda+i]
+		t[i] += f64.DotUnitary(atmp, x[:i]) + x[i]*a[i*lda+i]
+		f64.AxpyUnitaryTo(t[:i], x[i], atmp, t[:i])
+	}
+}
+
 // Dtbmv computes
 //  x = A * x if tA == blas.NoTrans
 //  x = A^T * x if tA == blas.Trans or blas.ConjTrans
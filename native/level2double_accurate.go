@@ -0,0 +1,178 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// DgemvExtra computes
+//  y = alpha * A * x + beta * y    if tA == blas.NoTrans
+//  y = alpha * A^T * x + beta * y  if tA == blas.Trans or blas.ConjTrans
+// where A is an m×n dense matrix, x and y are vectors, and alpha and beta are
+// scalars. Each element of y is computed as accurately as if the whole
+// expression were evaluated in twice the working precision and then rounded,
+// which makes DgemvExtra suitable for computing residuals in iterative
+// refinement. y is not referenced on input if beta is zero.
+func (impl Implementation) DgemvExtra(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	lenX, lenY := checkGemv(tA, m, n, len(a), lda, len(x), incX, len(y), incY)
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+
+	nWorkers := impl.level2Workers(m * n)
+	if tA == blas.NoTrans {
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			iy := ky + i0*incY
+			for i := i0; i < i1; i++ {
+				var d dot2
+				if alpha != 0 {
+					ix := kx
+					for _, v := range a[lda*i : lda*i+n] {
+						d.add(v, x[ix])
+						ix += incX
+					}
+				}
+				y[iy] = d.axpby(alpha, beta, y[iy])
+				iy += incY
+			}
+		})
+		return
+	}
+	// The rows of A are traversed in order, accumulating the sums for the
+	// columns j0 <= j < j1 at once.
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		d := make([]dot2, j1-j0)
+		if alpha != 0 {
+			ix := kx
+			for i := 0; i < m; i++ {
+				xi := x[ix]
+				for j, v := range a[lda*i+j0 : lda*i+j1] {
+					d[j].add(v, xi)
+				}
+				ix += incX
+			}
+		}
+		jy := ky + j0*incY
+		for j := range d {
+			y[jy] = d[j].axpby(alpha, beta, y[jy])
+			jy += incY
+		}
+	})
+}
+
+// DsymvExtra computes
+//  y = alpha * A * x + beta * y,
+// where A is an n×n symmetric matrix, x and y are vectors, and alpha and
+// beta are scalars. Each element of y is computed as accurately as if the
+// whole expression were evaluated in twice the working precision and then
+// rounded. y is not referenced on input if beta is zero.
+func (impl Implementation) DsymvExtra(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if n < 0 {
+		panic(negativeN)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	if (incX > 0 && (n-1)*incX >= len(x)) || (incX < 0 && (1-n)*incX >= len(x)) {
+		panic(badX)
+	}
+	if (incY > 0 && (n-1)*incY >= len(y)) || (incY < 0 && (1-n)*incY >= len(y)) {
+		panic(badY)
+	}
+	if lda*(n-1)+n > len(a) || lda < max(1, n) {
+		panic(badLdA)
+	}
+
+	// Quick return if possible
+	if n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(n - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(n - 1) * incY
+	}
+
+	parallelRange(impl.level2Workers(n*n), n, func(i0, i1 int) {
+		iy := ky + i0*incY
+		for i := i0; i < i1; i++ {
+			var d dot2
+			if alpha != 0 {
+				// Row i of A is read from the stored triangle, partly
+				// along column i.
+				ix := kx
+				for j := 0; j < n; j++ {
+					var v float64
+					if (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i) {
+						v = a[i*lda+j]
+					} else {
+						v = a[j*lda+i]
+					}
+					d.add(v, x[ix])
+					ix += incX
+				}
+			}
+			y[iy] = d.axpby(alpha, beta, y[iy])
+			iy += incY
+		}
+	})
+}
+
+// checkGemv panics if the arguments of a general matrix-vector product with
+// an m×n matrix of length lenA, a vector x of length lenx and a vector y of
+// length leny are not valid. It returns the number of elements of x and y
+// that are referenced.
+func checkGemv(tA blas.Transpose, m, n, lenA, lda, lenx, incX, leny, incY int) (lenX, lenY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic(badLdA)
+	}
+	if incX == 0 {
+		panic(zeroIncX)
+	}
+	if incY == 0 {
+		panic(zeroIncY)
+	}
+	lenX, lenY = m, n
+	if tA == blas.NoTrans {
+		lenX, lenY = n, m
+	}
+	if (incX > 0 && (lenX-1)*incX >= lenx) || (incX < 0 && (1-lenX)*incX >= lenx) {
+		panic(badX)
+	}
+	if (incY > 0 && (lenY-1)*incY >= leny) || (incY < 0 && (1-lenY)*incY >= leny) {
+		panic(badY)
+	}
+	if lda*(m-1)+n > lenA {
+		panic(badLdA)
+	}
+	return lenX, lenY
+}
@@ -0,0 +1,277 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// exactAxpby returns alpha * a^T x + beta * y rounded to float64, and a
+// bound on the allowed error of an extra-precision computation of it.
+func exactAxpby(alpha float64, a, x []float64, beta, y float64) (want, tol float64) {
+	sum := new(big.Float).SetPrec(2048)
+	var abs float64
+	for i := range a {
+		p := new(big.Float).SetPrec(2048).SetFloat64(a[i])
+		p.Mul(p, new(big.Float).SetFloat64(x[i]))
+		sum.Add(sum, p)
+		abs += math.Abs(a[i] * x[i])
+	}
+	sum.Mul(sum, new(big.Float).SetFloat64(alpha))
+	p := new(big.Float).SetPrec(2048).SetFloat64(beta)
+	sum.Add(sum, p.Mul(p, new(big.Float).SetFloat64(y)))
+	want, _ = sum.Float64()
+	g := gamma(len(a) + 2)
+	abs = math.Abs(alpha)*abs + math.Abs(beta*y)
+	return want, 2*eps*math.Abs(want) + 2*g*g*abs
+}
+
+// illSystem returns an m×n matrix whose rows have ill-conditioned dot
+// products with x.
+func illSystem(rnd *rand.Rand, m, n, cond int) (a, x []float64) {
+	a = make([]float64, m*n)
+	x = make([]float64, n)
+	for j := range x {
+		x[j] = 2*rnd.Float64() - 1
+	}
+	for i := 0; i < m; i++ {
+		r, y := illDot(rnd, n, cond)
+		// Scale the row so that its dot product with x is as
+		// ill-conditioned as that of r with y.
+		for j := range x {
+			a[i*n+j] = r[j] * y[j] / x[j]
+		}
+	}
+	return a, x
+}
+
+func TestDgemvExtra(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n int }{{1, 1}, {3, 8}, {37, 29}, {120, 150}} {
+		m, n := test.m, test.n
+		for _, cond := range []int{0, 60, 120} {
+			if n < 6 && cond > 0 {
+				continue
+			}
+			a, xn := illSystem(rnd, m, n, cond)
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				lenY := m
+				x := xn
+				if tA == blas.Trans {
+					lenY = n
+					x = wideVec(rnd, m, 10)
+				}
+				y := wideVec(rnd, lenY, 10)
+				for _, ab := range []struct{ alpha, beta float64 }{{1, 0}, {-1, 1}, {1.5, -0.5}, {0, 2}} {
+					alpha, beta := ab.alpha, ab.beta
+					want := make([]float64, lenY)
+					tol := make([]float64, lenY)
+					col := make([]float64, m)
+					for i := range want {
+						var row []float64
+						if tA == blas.NoTrans {
+							row = a[i*n : (i+1)*n]
+						} else {
+							for k := range col {
+								col[k] = a[k*n+i]
+							}
+							row = col
+						}
+						want[i], tol[i] = exactAxpby(alpha, row, x, beta, y[i])
+					}
+					for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+						for _, inc := range level2Incs {
+							prefix := fmt.Sprintf("m=%v,n=%v,cond=%v,tA=%v,alpha=%v,beta=%v,incX=%v,incY=%v,%+v", m, n, cond, tA, alpha, beta, inc.x, inc.y, impl)
+							lda := n + 1
+							sa := make([]float64, m*lda)
+							for i := 0; i < m; i++ {
+								copy(sa[i*lda:], a[i*n:(i+1)*n])
+							}
+							sy := strided(y, inc.y, 0)
+							impl.DgemvExtra(tA, m, n, alpha, sa, lda, strided(x, inc.x, 1), inc.x, beta, sy, inc.y)
+							k := 0
+							if inc.y < 0 {
+								k = (lenY - 1) * -inc.y
+							}
+							for i, w := range want {
+								if math.Abs(sy[k]-w) > tol[i] {
+									t.Errorf("%v: inaccurate element %d: got %v, want %v", prefix, i, sy[k], w)
+									break
+								}
+								k += inc.y
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDgemvExtraBetaZero(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	x := []float64{1, 1}
+	y := []float64{math.NaN(), math.NaN()}
+	Implementation{}.DgemvExtra(blas.NoTrans, 2, 2, 1, a, 2, x, 1, 0, y, 1)
+	if y[0] != 3 || y[1] != 7 {
+		t.Errorf("unexpected result: got %v, want [3 7]", y)
+	}
+}
+
+func TestDgemvExtraSpecial(t *testing.T) {
+	inf := math.Inf(1)
+	// Row 0 has an operand too large to be split without overflow, row 1
+	// contains an infinity and row 2 overflows.
+	a := []float64{
+		1e305, 1,
+		inf, 1,
+		1e200, 1e200,
+	}
+	x := []float64{1e-10, 1}
+	y := make([]float64, 3)
+	Implementation{}.DgemvExtra(blas.NoTrans, 3, 2, 1, a, 2, x, 1, 0, y, 1)
+	if want, tol := exactAxpby(1, a[:2], x, 0, 0); math.Abs(y[0]-want) > tol {
+		t.Errorf("unexpected result for huge entry: got %v, want %v", y[0], want)
+	}
+	if y[1] != inf {
+		t.Errorf("unexpected result for infinite entry: got %v, want +Inf", y[1])
+	}
+	x = []float64{1e200, 1}
+	Implementation{}.DgemvExtra(blas.NoTrans, 3, 2, 1, a, 2, x, 1, 0, y, 1)
+	if y[2] != inf {
+		t.Errorf("unexpected result for overflow: got %v, want +Inf", y[2])
+	}
+
+	// Symmetric matrix with a huge off-diagonal entry and an infinite
+	// diagonal entry.
+	sa := []float64{
+		1, 1e305,
+		1e305, inf,
+	}
+	x = []float64{1, 1e-10}
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		y := []float64{1, 1}
+		Implementation{}.DsymvExtra(ul, 2, 1, sa, 2, x, 1, 1, y, 1)
+		if want, tol := exactAxpby(1, sa[:2], x, 1, 1); math.Abs(y[0]-want) > tol {
+			t.Errorf("ul=%v: unexpected result for huge entry: got %v, want %v", ul, y[0], want)
+		}
+		if y[1] != inf {
+			t.Errorf("ul=%v: unexpected result for infinite entry: got %v, want +Inf", ul, y[1])
+		}
+	}
+}
+
+func TestDsymvExtra(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 7, 300} {
+		a := wideVec(rnd, n*n, 20)
+		// Symmetrize a so that DgemvExtra gives the reference result.
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				a[i*n+j] = a[j*n+i]
+			}
+		}
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			// Only the ul triangle of sa is valid.
+			sa := make([]float64, len(a))
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i) {
+						sa[i*n+j] = a[i*n+j]
+					} else {
+						sa[i*n+j] = math.NaN()
+					}
+				}
+			}
+			for _, inc := range level2Incs {
+				prefix := fmt.Sprintf("n=%v,ul=%v,incX=%v,incY=%v", n, ul, inc.x, inc.y)
+				x := randvec(n, inc.x)
+				y := randvec(n, inc.y)
+				want := make([]float64, len(y))
+				copy(want, y)
+				Implementation{}.DgemvExtra(blas.NoTrans, n, n, 1.5, a, n, x, inc.x, 0.5, want, inc.y)
+				for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+					got := make([]float64, len(y))
+					copy(got, y)
+					impl.DsymvExtra(ul, n, 1.5, sa, n, x, inc.x, 0.5, got, inc.y)
+					if !sameFloat64s(got, want) {
+						t.Errorf("%v, %+v: result differs from DgemvExtra", prefix, impl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDsgemv(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n int }{{1, 1}, {3, 8}, {37, 29}, {300, 400}} {
+		m, n := test.m, test.n
+		a32 := make([]float32, m*(n+1))
+		a64 := make([]float64, len(a32))
+		for i := range a32 {
+			a32[i] = float32(rnd.NormFloat64())
+			a64[i] = float64(a32[i])
+		}
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA == blas.Trans {
+				lenX, lenY = m, n
+			}
+			for _, inc := range level2Incs {
+				prefix := fmt.Sprintf("m=%v,n=%v,tA=%v,incX=%v,incY=%v", m, n, tA, inc.x, inc.y)
+				x := randvec(lenX, inc.x)
+				y := randvec(lenY, inc.y)
+				x32 := make([]float32, len(x))
+				for i, v := range x {
+					x32[i] = float32(v)
+					x[i] = float64(x32[i])
+				}
+				y32 := make([]float32, len(y))
+				for i, v := range y {
+					y32[i] = float32(v)
+					y[i] = float64(y32[i])
+				}
+				want32 := make([]float64, len(y))
+				copy(want32, y)
+				Implementation{}.DgemvExtra(tA, m, n, 1.5, a64, n+1, x, inc.x, 0.5, want32, inc.y)
+
+				for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+					got := make([]float64, len(y))
+					copy(got, y)
+					impl.Dsgemv(tA, m, n, 1.5, a32, n+1, x, inc.x, 0.5, got, inc.y)
+					if !closeFloat64s(got, want32, 1e-13) {
+						t.Errorf("%v, %+v: unexpected Dsgemv result", prefix, impl)
+					}
+
+					got32 := make([]float32, len(y32))
+					copy(got32, y32)
+					impl.Sdsgemv(tA, m, n, 1.5, a32, n+1, x32, inc.x, 0.5, got32, inc.y)
+					for i, w := range want32 {
+						// The result is correctly rounded unless the
+						// float64 sum is close to a rounding boundary.
+						if got32[i] != float32(w) && math.Abs(float64(got32[i])-w) > 1e-7*math.Abs(w) {
+							t.Errorf("%v, %+v: unexpected Sdsgemv element %d: got %v, want %v", prefix, impl, i, got32[i], float32(w))
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+var _ blas.Float32Mixed = Implementation{}
+
+// Dsgemv computes
+//  y = alpha * A * x + beta * y    if tA == blas.NoTrans
+//  y = alpha * A^T * x + beta * y  if tA == blas.Trans or blas.ConjTrans
+// where A is an m×n dense single precision matrix, x and y are double
+// precision vectors, and alpha and beta are scalars. The products are
+// accumulated in double precision, so Dsgemv can compute the residual of a
+// system whose matrix is stored in single precision. y is not referenced on
+// input if beta is zero.
+func (impl Implementation) Dsgemv(tA blas.Transpose, m, n int, alpha float64, a []float32, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	lenX, lenY := checkGemv(tA, m, n, len(a), lda, len(x), incX, len(y), incY)
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+
+	nWorkers := impl.level2Workers(m * n)
+	if tA == blas.NoTrans {
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			iy := ky + i0*incY
+			for i := i0; i < i1; i++ {
+				var sum float64
+				if alpha != 0 {
+					ix := kx
+					for _, v := range a[lda*i : lda*i+n] {
+						sum += float64(v) * x[ix]
+						ix += incX
+					}
+				}
+				y[iy] = axpby(alpha, sum, beta, y[iy])
+				iy += incY
+			}
+		})
+		return
+	}
+	// The rows of A are traversed in order, accumulating the sums for the
+	// columns j0 <= j < j1 at once.
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		sum := make([]float64, j1-j0)
+		if alpha != 0 {
+			ix := kx
+			for i := 0; i < m; i++ {
+				xi := x[ix]
+				for j, v := range a[lda*i+j0 : lda*i+j1] {
+					sum[j] += float64(v) * xi
+				}
+				ix += incX
+			}
+		}
+		jy := ky + j0*incY
+		for _, s := range sum {
+			y[jy] = axpby(alpha, s, beta, y[jy])
+			jy += incY
+		}
+	})
+}
+
+// Sdsgemv computes
+//  y = alpha * A * x + beta * y    if tA == blas.NoTrans
+//  y = alpha * A^T * x + beta * y  if tA == blas.Trans or blas.ConjTrans
+// where A is an m×n dense matrix, x and y are vectors, and alpha and beta are
+// scalars, all in single precision. The expression is evaluated in double
+// precision, in which the products of single precision values are exact, and
+// only the result is rounded to single precision. y is not referenced on
+// input if beta is zero.
+func (impl Implementation) Sdsgemv(tA blas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	lenX, lenY := checkGemv(tA, m, n, len(a), lda, len(x), incX, len(y), incY)
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	var kx, ky int
+	if incX < 0 {
+		kx = -(lenX - 1) * incX
+	}
+	if incY < 0 {
+		ky = -(lenY - 1) * incY
+	}
+
+	nWorkers := impl.level2Workers(m * n)
+	if tA == blas.NoTrans {
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			iy := ky + i0*incY
+			for i := i0; i < i1; i++ {
+				var sum float64
+				if alpha != 0 {
+					ix := kx
+					for _, v := range a[lda*i : lda*i+n] {
+						sum += float64(v) * float64(x[ix])
+						ix += incX
+					}
+				}
+				y[iy] = float32(axpby(float64(alpha), sum, float64(beta), float64(y[iy])))
+				iy += incY
+			}
+		})
+		return
+	}
+	// The rows of A are traversed in order, accumulating the sums for the
+	// columns j0 <= j < j1 at once.
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		sum := make([]float64, j1-j0)
+		if alpha != 0 {
+			ix := kx
+			for i := 0; i < m; i++ {
+				xi := float64(x[ix])
+				for j, v := range a[lda*i+j0 : lda*i+j1] {
+					sum[j] += float64(v) * xi
+				}
+				ix += incX
+			}
+		}
+		jy := ky + j0*incY
+		for _, s := range sum {
+			y[jy] = float32(axpby(float64(alpha), s, float64(beta), float64(y[jy])))
+			jy += incY
+		}
+	})
+}
+
+// axpby returns alpha * s + beta * y. y is not referenced if beta is zero.
+func axpby(alpha, s, beta, y float64) float64 {
+	if beta == 0 {
+		return alpha * s
+	}
+	return alpha*s + beta*y
+}
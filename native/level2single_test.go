@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func TestSgemv(t *testing.T) {
+	testblas.SgemvTest(t, impl)
+}
+
+func TestSgbmv(t *testing.T) {
+	testblas.SgbmvTest(t, impl)
+}
+
+func TestSsymv(t *testing.T) {
+	testblas.SsymvTest(t, impl)
+}
+
+func TestSsbmv(t *testing.T) {
+	testblas.SsbmvTest(t, impl)
+}
+
+func TestSspmv(t *testing.T) {
+	testblas.SspmvTest(t, impl)
+}
+
+func TestStrmv(t *testing.T) {
+	testblas.StrmvTest(t, impl)
+}
+
+func TestStbmv(t *testing.T) {
+	testblas.StbmvTest(t, impl)
+}
+
+func TestStpmv(t *testing.T) {
+	testblas.StpmvTest(t, impl)
+}
+
+func TestStrsv(t *testing.T) {
+	testblas.StrsvTest(t, impl)
+}
+
+func TestStbsv(t *testing.T) {
+	testblas.StbsvTest(t, impl)
+}
+
+func TestStpsv(t *testing.T) {
+	testblas.StpsvTest(t, impl)
+}
+
+func TestSger(t *testing.T) {
+	testblas.SgerTest(t, impl)
+}
+
+func TestSsyr(t *testing.T) {
+	testblas.SsyrTest(t, impl)
+}
+
+func TestSspr(t *testing.T) {
+	testblas.SsprTest(t, impl)
+}
+
+func TestSsyr2(t *testing.T) {
+	testblas.Ssyr2Test(t, impl)
+}
+
+func TestSspr2(t *testing.T) {
+	testblas.Sspr2Test(t, impl)
+}
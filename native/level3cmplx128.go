@@ -0,0 +1,976 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math/cmplx"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/internal/asm/c128"
+)
+
+var _ blas.Complex128Level3 = Implementation{}
+
+// Zhemm performs one of the matrix-matrix operations
+//  C = alpha * A * B + beta * C, if s == blas.Left,
+//  C = alpha * B * A + beta * C, if s == blas.Right,
+// where alpha and beta are scalars, A is an m×m or n×n Hermitian matrix and B
+// and C are m×n matrices. The imaginary parts of the diagonal elements of A are
+// assumed to be zero.
+func (Implementation) Zhemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	na := m
+	if s == blas.Right {
+		na = n
+	}
+	if lda < max(1, na) || lda*(na-1)+na > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) || ldb*(m-1)+n > len(b) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) || ldc*(m-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else {
+				c128.ScalUnitary(beta, ctmp)
+			}
+		}
+		return
+	}
+
+	if s == blas.Left {
+		// Form  C = alpha*A*B + beta*C.
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else if beta != 1 {
+				c128.ScalUnitary(beta, ctmp)
+			}
+			if ul == blas.Upper {
+				for k := 0; k < i; k++ {
+					c128.AxpyUnitary(alpha*cmplx.Conj(a[k*lda+i]), b[k*ldb:k*ldb+n], ctmp)
+				}
+				c128.AxpyUnitary(alpha*complex(real(a[i*lda+i]), 0), b[i*ldb:i*ldb+n], ctmp)
+				for k := i + 1; k < m; k++ {
+					c128.AxpyUnitary(alpha*a[i*lda+k], b[k*ldb:k*ldb+n], ctmp)
+				}
+			} else {
+				for k := 0; k < i; k++ {
+					c128.AxpyUnitary(alpha*a[i*lda+k], b[k*ldb:k*ldb+n], ctmp)
+				}
+				c128.AxpyUnitary(alpha*complex(real(a[i*lda+i]), 0), b[i*ldb:i*ldb+n], ctmp)
+				for k := i + 1; k < m; k++ {
+					c128.AxpyUnitary(alpha*cmplx.Conj(a[k*lda+i]), b[k*ldb:k*ldb+n], ctmp)
+				}
+			}
+		}
+		return
+	}
+
+	// Form  C = alpha*B*A + beta*C.
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ctmp)
+		}
+		for k, v := range b[i*ldb : i*ldb+n] {
+			tmp := alpha * v
+			if ul == blas.Upper {
+				for j := 0; j < k; j++ {
+					ctmp[j] += tmp * cmplx.Conj(a[j*lda+k])
+				}
+				ctmp[k] += tmp * complex(real(a[k*lda+k]), 0)
+				c128.AxpyUnitary(tmp, a[k*lda+k+1:k*lda+n], ctmp[k+1:])
+			} else {
+				c128.AxpyUnitary(tmp, a[k*lda:k*lda+k], ctmp[:k])
+				ctmp[k] += tmp * complex(real(a[k*lda+k]), 0)
+				for j := k + 1; j < n; j++ {
+					ctmp[j] += tmp * cmplx.Conj(a[j*lda+k])
+				}
+			}
+		}
+	}
+}
+
+// Zher2k performs one of the Hermitian rank-2k operations
+//  C = alpha*A*B^H + conj(alpha)*B*A^H + beta*C  if tA == blas.NoTrans
+//  C = alpha*A^H*B + conj(alpha)*B^H*A + beta*C  if tA == blas.ConjTrans
+// where alpha is a complex scalar, beta is a real scalar, C is an n×n Hermitian
+// matrix and A and B are n×k matrices in the first case and k×n matrices in the
+// second case.
+//
+// The imaginary parts of the diagonal elements of C are assumed to be zero, and
+// on return they will be set to zero.
+func (Implementation) Zher2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	row, col := n, k
+	if tA == blas.ConjTrans {
+		row, col = k, n
+	}
+	if lda < max(1, col) || lda*(row-1)+col > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, col) || ldb*(row-1)+col > len(b) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) || ldc*(n-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		ctmp := c[i*ldc+jStart : i*ldc+jEnd]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			for j, v := range ctmp {
+				ctmp[j] = complex(beta*real(v), beta*imag(v))
+			}
+		}
+		if alpha != 0 {
+			if tA == blas.NoTrans {
+				// Form  C = alpha*A*B^H + conj(alpha)*B*A^H + beta*C.
+				atmp := a[i*lda : i*lda+k]
+				btmp := b[i*ldb : i*ldb+k]
+				for jc := range ctmp {
+					j := jStart + jc
+					ctmp[jc] += alpha*c128.DotcUnitary(b[j*ldb:j*ldb+k], atmp) +
+						cmplx.Conj(alpha)*c128.DotcUnitary(a[j*lda:j*lda+k], btmp)
+				}
+			} else {
+				// Form  C = alpha*A^H*B + conj(alpha)*B^H*A + beta*C.
+				for l := 0; l < k; l++ {
+					tmp1 := alpha * cmplx.Conj(a[l*lda+i])
+					tmp2 := cmplx.Conj(alpha * b[l*ldb+i])
+					if tmp1 != 0 {
+						c128.AxpyUnitary(tmp1, b[l*ldb+jStart:l*ldb+jEnd], ctmp)
+					}
+					if tmp2 != 0 {
+						c128.AxpyUnitary(tmp2, a[l*lda+jStart:l*lda+jEnd], ctmp)
+					}
+				}
+			}
+		}
+		c[i*ldc+i] = complex(real(c[i*ldc+i]), 0)
+	}
+}
+
+// Zherk performs one of the Hermitian rank-k operations
+//  C = alpha*A*A^H + beta*C  if tA == blas.NoTrans
+//  C = alpha*A^H*A + beta*C  if tA == blas.ConjTrans
+// where alpha and beta are real scalars, C is an n×n Hermitian matrix and A is
+// an n×k matrix in the first case and a k×n matrix in the second case.
+//
+// The imaginary parts of the diagonal elements of C are assumed to be zero, and
+// on return they will be set to zero.
+func (Implementation) Zherk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	row, col := n, k
+	if tA == blas.ConjTrans {
+		row, col = k, n
+	}
+	if lda < max(1, col) || lda*(row-1)+col > len(a) {
+		panic(badLdA)
+	}
+	if ldc < max(1, n) || ldc*(n-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	calpha := complex(alpha, 0)
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		ctmp := c[i*ldc+jStart : i*ldc+jEnd]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			for j, v := range ctmp {
+				ctmp[j] = complex(beta*real(v), beta*imag(v))
+			}
+		}
+		if alpha != 0 {
+			if tA == blas.NoTrans {
+				// Form  C = alpha*A*A^H + beta*C.
+				atmp := a[i*lda : i*lda+k]
+				for jc := range ctmp {
+					j := jStart + jc
+					ctmp[jc] += calpha * c128.DotcUnitary(a[j*lda:j*lda+k], atmp)
+				}
+			} else {
+				// Form  C = alpha*A^H*A + beta*C.
+				for l := 0; l < k; l++ {
+					tmp := calpha * cmplx.Conj(a[l*lda+i])
+					if tmp != 0 {
+						c128.AxpyUnitary(tmp, a[l*lda+jStart:l*lda+jEnd], ctmp)
+					}
+				}
+			}
+		}
+		c[i*ldc+i] = complex(real(c[i*ldc+i]), 0)
+	}
+}
+
+// Zsymm performs one of the matrix-matrix operations
+//  C = alpha * A * B + beta * C, if s == blas.Left,
+//  C = alpha * B * A + beta * C, if s == blas.Right,
+// where alpha and beta are scalars, A is an m×m or n×n symmetric matrix and B
+// and C are m×n matrices.
+func (Implementation) Zsymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	na := m
+	if s == blas.Right {
+		na = n
+	}
+	if lda < max(1, na) || lda*(na-1)+na > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) || ldb*(m-1)+n > len(b) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) || ldc*(m-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else {
+				c128.ScalUnitary(beta, ctmp)
+			}
+		}
+		return
+	}
+
+	if s == blas.Left {
+		// Form  C = alpha*A*B + beta*C.
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else if beta != 1 {
+				c128.ScalUnitary(beta, ctmp)
+			}
+			for k := 0; k < m; k++ {
+				var aik complex128
+				if (ul == blas.Upper) == (k >= i) {
+					aik = a[i*lda+k]
+				} else {
+					aik = a[k*lda+i]
+				}
+				c128.AxpyUnitary(alpha*aik, b[k*ldb:k*ldb+n], ctmp)
+			}
+		}
+		return
+	}
+
+	// Form  C = alpha*B*A + beta*C.
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ctmp)
+		}
+		for k, v := range b[i*ldb : i*ldb+n] {
+			tmp := alpha * v
+			if ul == blas.Upper {
+				c128.AxpyInc(tmp, a[k:], ctmp, uintptr(k), uintptr(lda), 1, 0, 0)
+				c128.AxpyUnitary(tmp, a[k*lda+k:k*lda+n], ctmp[k:])
+			} else {
+				c128.AxpyUnitary(tmp, a[k*lda:k*lda+k+1], ctmp[:k+1])
+				if k+1 < n {
+					c128.AxpyInc(tmp, a[(k+1)*lda+k:], ctmp[k+1:], uintptr(n-k-1), uintptr(lda), 1, 0, 0)
+				}
+			}
+		}
+	}
+}
+
+// Zsyr2k performs one of the symmetric rank-2k operations
+//  C = alpha*A*B^T + alpha*B*A^T + beta*C  if tA == blas.NoTrans
+//  C = alpha*A^T*B + alpha*B^T*A + beta*C  if tA == blas.Trans
+// where alpha and beta are scalars, C is an n×n symmetric matrix and A and B
+// are n×k matrices in the first case and k×n matrices in the second case.
+func (Implementation) Zsyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans {
+		panic(badTranspose)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	row, col := n, k
+	if tA == blas.Trans {
+		row, col = k, n
+	}
+	if lda < max(1, col) || lda*(row-1)+col > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, col) || ldb*(row-1)+col > len(b) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) || ldc*(n-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		ctmp := c[i*ldc+jStart : i*ldc+jEnd]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ctmp)
+		}
+		if alpha == 0 {
+			continue
+		}
+		if tA == blas.NoTrans {
+			// Form  C = alpha*A*B^T + alpha*B*A^T + beta*C.
+			atmp := a[i*lda : i*lda+k]
+			btmp := b[i*ldb : i*ldb+k]
+			for jc := range ctmp {
+				j := jStart + jc
+				ctmp[jc] += alpha * (c128.DotuUnitary(atmp, b[j*ldb:j*ldb+k]) + c128.DotuUnitary(btmp, a[j*lda:j*lda+k]))
+			}
+			continue
+		}
+		// Form  C = alpha*A^T*B + alpha*B^T*A + beta*C.
+		for l := 0; l < k; l++ {
+			tmp1 := alpha * a[l*lda+i]
+			tmp2 := alpha * b[l*ldb+i]
+			if tmp1 != 0 {
+				c128.AxpyUnitary(tmp1, b[l*ldb+jStart:l*ldb+jEnd], ctmp)
+			}
+			if tmp2 != 0 {
+				c128.AxpyUnitary(tmp2, a[l*lda+jStart:l*lda+jEnd], ctmp)
+			}
+		}
+	}
+}
+
+// Zsyrk performs one of the symmetric rank-k operations
+//  C = alpha*A*A^T + beta*C  if tA == blas.NoTrans
+//  C = alpha*A^T*A + beta*C  if tA == blas.Trans
+// where alpha and beta are scalars, C is an n×n symmetric matrix and A is
+// an n×k matrix in the first case and a k×n matrix in the second case.
+func (Implementation) Zsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans {
+		panic(badTranspose)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	row, col := n, k
+	if tA == blas.Trans {
+		row, col = k, n
+	}
+	if lda < max(1, col) || lda*(row-1)+col > len(a) {
+		panic(badLdA)
+	}
+	if ldc < max(1, n) || ldc*(n-1)+n > len(c) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		ctmp := c[i*ldc+jStart : i*ldc+jEnd]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
+			c128.ScalUnitary(beta, ctmp)
+		}
+		if alpha == 0 {
+			continue
+		}
+		if tA == blas.NoTrans {
+			// Form  C = alpha*A*A^T + beta*C.
+			atmp := a[i*lda : i*lda+k]
+			for jc := range ctmp {
+				j := jStart + jc
+				ctmp[jc] += alpha * c128.DotuUnitary(atmp, a[j*lda:j*lda+k])
+			}
+			continue
+		}
+		// Form  C = alpha*A^T*A + beta*C.
+		for l := 0; l < k; l++ {
+			tmp := alpha * a[l*lda+i]
+			if tmp != 0 {
+				c128.AxpyUnitary(tmp, a[l*lda+jStart:l*lda+jEnd], ctmp)
+			}
+		}
+	}
+}
+
+// Ztrmm performs one of the matrix-matrix operations
+//  B = alpha * op(A) * B  if s == blas.Left,
+//  B = alpha * B * op(A)  if s == blas.Right,
+// where alpha is a scalar, B is an m×n matrix, A is a unit, or non-unit,
+// upper or lower triangular matrix and op(A) is one of
+//  op(A) = A    if tA == blas.NoTrans,
+//  op(A) = A^T  if tA == blas.Trans,
+//  op(A) = A^H  if tA == blas.ConjTrans.
+func (Implementation) Ztrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	na := m
+	if s == blas.Right {
+		na = n
+	}
+	if lda < max(1, na) || lda*(na-1)+na > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) || ldb*(m-1)+n > len(b) {
+		panic(badLdB)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			for j := range btmp {
+				btmp[j] = 0
+			}
+		}
+		return
+	}
+
+	noConj := tA != blas.ConjTrans
+	nonUnit := d == blas.NonUnit
+	if s == blas.Left {
+		if tA == blas.NoTrans {
+			if ul == blas.Upper {
+				for i := 0; i < m; i++ {
+					tmp := alpha
+					if nonUnit {
+						tmp *= a[i*lda+i]
+					}
+					btmp := b[i*ldb : i*ldb+n]
+					c128.ScalUnitary(tmp, btmp)
+					for ka, va := range a[i*lda+i+1 : i*lda+m] {
+						k := ka + i + 1
+						if va != 0 {
+							c128.AxpyUnitary(alpha*va, b[k*ldb:k*ldb+n], btmp)
+						}
+					}
+				}
+				return
+			}
+			for i := m - 1; i >= 0; i-- {
+				tmp := alpha
+				if nonUnit {
+					tmp *= a[i*lda+i]
+				}
+				btmp := b[i*ldb : i*ldb+n]
+				c128.ScalUnitary(tmp, btmp)
+				for k, va := range a[i*lda : i*lda+i] {
+					if va != 0 {
+						c128.AxpyUnitary(alpha*va, b[k*ldb:k*ldb+n], btmp)
+					}
+				}
+			}
+			return
+		}
+		// Cases where a is transposed or conjugate transposed.
+		if ul == blas.Upper {
+			for k := m - 1; k >= 0; k-- {
+				btmpk := b[k*ldb : k*ldb+n]
+				for ia, va := range a[k*lda+k+1 : k*lda+m] {
+					i := ia + k + 1
+					if va != 0 {
+						if !noConj {
+							va = cmplx.Conj(va)
+						}
+						c128.AxpyUnitary(alpha*va, btmpk, b[i*ldb:i*ldb+n])
+					}
+				}
+				tmp := alpha
+				if nonUnit {
+					aii := a[k*lda+k]
+					if !noConj {
+						aii = cmplx.Conj(aii)
+					}
+					tmp *= aii
+				}
+				if tmp != 1 {
+					c128.ScalUnitary(tmp, btmpk)
+				}
+			}
+			return
+		}
+		for k := 0; k < m; k++ {
+			btmpk := b[k*ldb : k*ldb+n]
+			for i, va := range a[k*lda : k*lda+k] {
+				if va != 0 {
+					if !noConj {
+						va = cmplx.Conj(va)
+					}
+					c128.AxpyUnitary(alpha*va, btmpk, b[i*ldb:i*ldb+n])
+				}
+			}
+			tmp := alpha
+			if nonUnit {
+				aii := a[k*lda+k]
+				if !noConj {
+					aii = cmplx.Conj(aii)
+				}
+				tmp *= aii
+			}
+			if tmp != 1 {
+				c128.ScalUnitary(tmp, btmpk)
+			}
+		}
+		return
+	}
+	// Cases where a is on the right.
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			for i := 0; i < m; i++ {
+				btmp := b[i*ldb : i*ldb+n]
+				for k := n - 1; k >= 0; k-- {
+					tmp := alpha * btmp[k]
+					if tmp != 0 {
+						btmp[k] = tmp
+						if nonUnit {
+							btmp[k] *= a[k*lda+k]
+						}
+						c128.AxpyUnitary(tmp, a[k*lda+k+1:k*lda+n], btmp[k+1:n])
+					} else {
+						btmp[k] = 0
+					}
+				}
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			for k := 0; k < n; k++ {
+				tmp := alpha * btmp[k]
+				if tmp != 0 {
+					btmp[k] = tmp
+					if nonUnit {
+						btmp[k] *= a[k*lda+k]
+					}
+					c128.AxpyUnitary(tmp, a[k*lda:k*lda+k], btmp[:k])
+				} else {
+					btmp[k] = 0
+				}
+			}
+		}
+		return
+	}
+	// Cases where a is transposed or conjugate transposed.
+	if ul == blas.Upper {
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			for j, vb := range btmp {
+				tmp := vb
+				if nonUnit {
+					ajj := a[j*lda+j]
+					if !noConj {
+						ajj = cmplx.Conj(ajj)
+					}
+					tmp *= ajj
+				}
+				if noConj {
+					tmp += c128.DotuUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:n])
+				} else {
+					tmp += c128.DotcUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:n])
+				}
+				btmp[j] = alpha * tmp
+			}
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		btmp := b[i*ldb : i*ldb+n]
+		for j := n - 1; j >= 0; j-- {
+			tmp := btmp[j]
+			if nonUnit {
+				ajj := a[j*lda+j]
+				if !noConj {
+					ajj = cmplx.Conj(ajj)
+				}
+				tmp *= ajj
+			}
+			if noConj {
+				tmp += c128.DotuUnitary(a[j*lda:j*lda+j], btmp[:j])
+			} else {
+				tmp += c128.DotcUnitary(a[j*lda:j*lda+j], btmp[:j])
+			}
+			btmp[j] = alpha * tmp
+		}
+	}
+}
+
+// Ztrsm solves one of the matrix equations
+//  op(A) * X = alpha * B  if s == blas.Left,
+//  X * op(A) = alpha * B  if s == blas.Right,
+// where alpha is a scalar, X and B are m×n matrices, A is a unit or
+// non-unit, upper or lower triangular matrix and op(A) is one of
+//  op(A) = A    if tA == blas.NoTrans,
+//  op(A) = A^T  if tA == blas.Trans,
+//  op(A) = A^H  if tA == blas.ConjTrans.
+// On entry, B contains the right-hand side matrix; on return it is overwritten
+// with the solution matrix X.
+//
+// No check is made that A is invertible.
+func (Implementation) Ztrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	na := m
+	if s == blas.Right {
+		na = n
+	}
+	if lda < max(1, na) || lda*(na-1)+na > len(a) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) || ldb*(m-1)+n > len(b) {
+		panic(badLdB)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if alpha == 0 {
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			for j := range btmp {
+				btmp[j] = 0
+			}
+		}
+		return
+	}
+
+	noConj := tA != blas.ConjTrans
+	nonUnit := d == blas.NonUnit
+	if s == blas.Left {
+		if tA == blas.NoTrans {
+			if ul == blas.Upper {
+				for i := m - 1; i >= 0; i-- {
+					btmp := b[i*ldb : i*ldb+n]
+					if alpha != 1 {
+						c128.ScalUnitary(alpha, btmp)
+					}
+					for ka, va := range a[i*lda+i+1 : i*lda+m] {
+						k := ka + i + 1
+						if va != 0 {
+							c128.AxpyUnitary(-va, b[k*ldb:k*ldb+n], btmp)
+						}
+					}
+					if nonUnit {
+						c128.ScalUnitary(1/a[i*lda+i], btmp)
+					}
+				}
+				return
+			}
+			for i := 0; i < m; i++ {
+				btmp := b[i*ldb : i*ldb+n]
+				if alpha != 1 {
+					c128.ScalUnitary(alpha, btmp)
+				}
+				for k, va := range a[i*lda : i*lda+i] {
+					if va != 0 {
+						c128.AxpyUnitary(-va, b[k*ldb:k*ldb+n], btmp)
+					}
+				}
+				if nonUnit {
+					c128.ScalUnitary(1/a[i*lda+i], btmp)
+				}
+			}
+			return
+		}
+		// Cases where a is transposed or conjugate transposed.
+		if ul == blas.Upper {
+			for k := 0; k < m; k++ {
+				btmpk := b[k*ldb : k*ldb+n]
+				if nonUnit {
+					akk := a[k*lda+k]
+					if !noConj {
+						akk = cmplx.Conj(akk)
+					}
+					c128.ScalUnitary(1/akk, btmpk)
+				}
+				for ia, va := range a[k*lda+k+1 : k*lda+m] {
+					i := ia + k + 1
+					if va != 0 {
+						if !noConj {
+							va = cmplx.Conj(va)
+						}
+						c128.AxpyUnitary(-va, btmpk, b[i*ldb:i*ldb+n])
+					}
+				}
+				if alpha != 1 {
+					c128.ScalUnitary(alpha, btmpk)
+				}
+			}
+			return
+		}
+		for k := m - 1; k >= 0; k-- {
+			btmpk := b[k*ldb : k*ldb+n]
+			if nonUnit {
+				akk := a[k*lda+k]
+				if !noConj {
+					akk = cmplx.Conj(akk)
+				}
+				c128.ScalUnitary(1/akk, btmpk)
+			}
+			for i, va := range a[k*lda : k*lda+k] {
+				if va != 0 {
+					if !noConj {
+						va = cmplx.Conj(va)
+					}
+					c128.AxpyUnitary(-va, btmpk, b[i*ldb:i*ldb+n])
+				}
+			}
+			if alpha != 1 {
+				c128.ScalUnitary(alpha, btmpk)
+			}
+		}
+		return
+	}
+	// Cases where a is to the right of X.
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			for i := 0; i < m; i++ {
+				btmp := b[i*ldb : i*ldb+n]
+				if alpha != 1 {
+					c128.ScalUnitary(alpha, btmp)
+				}
+				for k := 0; k < n; k++ {
+					if btmp[k] != 0 {
+						if nonUnit {
+							btmp[k] /= a[k*lda+k]
+						}
+						c128.AxpyUnitary(-btmp[k], a[k*lda+k+1:k*lda+n], btmp[k+1:n])
+					}
+				}
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			if alpha != 1 {
+				c128.ScalUnitary(alpha, btmp)
+			}
+			for k := n - 1; k >= 0; k-- {
+				if btmp[k] != 0 {
+					if nonUnit {
+						btmp[k] /= a[k*lda+k]
+					}
+					c128.AxpyUnitary(-btmp[k], a[k*lda:k*lda+k], btmp[:k])
+				}
+			}
+		}
+		return
+	}
+	// Cases where a is transposed or conjugate transposed.
+	if ul == blas.Upper {
+		for i := 0; i < m; i++ {
+			btmp := b[i*ldb : i*ldb+n]
+			for j := n - 1; j >= 0; j-- {
+				tmp := alpha * btmp[j]
+				if noConj {
+					tmp -= c128.DotuUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:])
+				} else {
+					tmp -= c128.DotcUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:])
+				}
+				if nonUnit {
+					ajj := a[j*lda+j]
+					if !noConj {
+						ajj = cmplx.Conj(ajj)
+					}
+					tmp /= ajj
+				}
+				btmp[j] = tmp
+			}
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		btmp := b[i*ldb : i*ldb+n]
+		for j := 0; j < n; j++ {
+			tmp := alpha * btmp[j]
+			if noConj {
+				tmp -= c128.DotuUnitary(a[j*lda:j*lda+j], btmp[:j])
+			} else {
+				tmp -= c128.DotcUnitary(a[j*lda:j*lda+j], btmp[:j])
+			}
+			if nonUnit {
+				ajj := a[j*lda+j]
+				if !noConj {
+					ajj = cmplx.Conj(ajj)
+				}
+				tmp /= ajj
+			}
+			btmp[j] = tmp
+		}
+	}
+}
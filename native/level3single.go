@@ -25,9 +25,7 @@ var _ blas.Float32Level3 = Implementation{}
 // stored in place into X.
 //
 // No check is made that A is invertible.
-//
-// Float32 implementations are autogenerated and not directly tested.
-func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+func (impl Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	if s != blas.Left && s != blas.Right {
 		panic(badSide)
 	}
@@ -75,6 +73,222 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	bs := impl.blockSize()
+	if k <= bs {
+		impl.parallelRHS(s, m, n, func(i, j, r, c int) {
+			strsmSerial(s, ul, tA, d, r, c, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
+	// Solve with bs×bs diagonal blocks of A. The right-hand sides of each
+	// diagonal block are solved concurrently, and the solution is then
+	// removed from the remaining right-hand sides using Sgemm.
+	sscaleBlock(m, n, alpha, b, ldb)
+	upper := ul == blas.Upper
+	trans := tA != blas.NoTrans
+	if s == blas.Left {
+		forward := upper == trans
+		for bi := 0; bi < blocks(m, bs); bi++ {
+			i0 := bi * bs
+			if !forward {
+				i0 = (blocks(m, bs) - 1 - bi) * bs
+			}
+			size := min(bs, m-i0)
+			i1 := i0 + size
+			impl.parallelRHS(s, size, n, func(i, j, r, c int) {
+				strsmSerial(s, ul, tA, d, r, c, 1, a[i0*lda+i0:], lda, b[(i0+i)*ldb+j:], ldb)
+			})
+			switch {
+			case !trans && upper && i0 > 0:
+				impl.sgemmParallel(false, false, i0, n, size, a[i0:], lda, b[i0*ldb:], ldb, b, ldb, -1)
+			case !trans && !upper && i1 < m:
+				impl.sgemmParallel(false, false, m-i1, n, size, a[i1*lda+i0:], lda, b[i0*ldb:], ldb, b[i1*ldb:], ldb, -1)
+			case trans && upper && i1 < m:
+				impl.sgemmParallel(true, false, m-i1, n, size, a[i0*lda+i1:], lda, b[i0*ldb:], ldb, b[i1*ldb:], ldb, -1)
+			case trans && !upper && i0 > 0:
+				impl.sgemmParallel(true, false, i0, n, size, a[i0*lda:], lda, b[i0*ldb:], ldb, b, ldb, -1)
+			}
+		}
+		return
+	}
+	forward := upper != trans
+	for bj := 0; bj < blocks(n, bs); bj++ {
+		j0 := bj * bs
+		if !forward {
+			j0 = (blocks(n, bs) - 1 - bj) * bs
+		}
+		size := min(bs, n-j0)
+		j1 := j0 + size
+		impl.parallelRHS(s, m, size, func(i, j, r, c int) {
+			strsmSerial(s, ul, tA, d, r, c, 1, a[j0*lda+j0:], lda, b[i*ldb+j0+j:], ldb)
+		})
+		switch {
+		case !trans && upper && j1 < n:
+			impl.sgemmParallel(false, false, m, n-j1, size, b[j0:], ldb, a[j0*lda+j1:], lda, b[j1:], ldb, -1)
+		case !trans && !upper && j0 > 0:
+			impl.sgemmParallel(false, false, m, j0, size, b[j0:], ldb, a[j0*lda:], lda, b, ldb, -1)
+		case trans && upper && j0 > 0:
+			impl.sgemmParallel(false, true, m, j0, size, b[j0:], ldb, a[j0:], lda, b, ldb, -1)
+		case trans && !upper && j1 < n:
+			impl.sgemmParallel(false, true, m, n-j1, size, b[j0:], ldb, a[j1*lda+j0:], lda, b[j1:], ldb, -1)
+		}
+	}
+}
+
+// StrsmBatched solves
+//  A[i] * X[i] = alpha * B[i],   if tA == blas.NoTrans side == blas.Left,
+//  A[i]^T * X[i] = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
+//  X[i] * A[i] = alpha * B[i],   if tA == blas.NoTrans side == blas.Right,
+//  X[i] * A[i]^T = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+// for each system i in the batch, where the A[i] are triangular matrices held
+// in a[i], the X[i] and B[i] are m×n matrices held in b[i], and alpha is a
+// scalar. All of the matrices of the batch have the sizes and strides of a
+// single call to Strsm. a and b must have the same length, and the B[i] must
+// not overlap.
+//
+// At entry to the function, X[i] contains the values of B[i], and the result
+// is stored in place into X[i]. The systems are shared between the workers
+// of impl, and each of them is solved on a single goroutine.
+//
+// No check is made that the A[i] are invertible.
+func (impl Implementation) StrsmBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	if lda < max(1, k) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) {
+		panic(badLdB)
+	}
+	if len(a) != len(b) {
+		panic(badBatchLen)
+	}
+	for i := range b {
+		if lda*(k-1)+k > len(a[i]) {
+			panic(batchEntry(badLdA, i))
+		}
+		if ldb*(m-1)+n > len(b[i]) {
+			panic(batchEntry(badLdB, i))
+		}
+	}
+
+	if m == 0 || n == 0 || len(b) == 0 {
+		return
+	}
+
+	nWorkers := impl.batchWorkers(len(b) * m * n * k)
+	parallelRange(nWorkers, len(b), func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			if alpha == 0 {
+				sscaleBlock(m, n, 0, b[i], ldb)
+				continue
+			}
+			strsmSerial(s, ul, tA, d, m, n, alpha, a[i], lda, b[i], ldb)
+		}
+	})
+}
+
+// StrsmStridedBatched solves
+//  A[i] * X[i] = alpha * B[i],   if tA == blas.NoTrans side == blas.Left,
+//  A[i]^T * X[i] = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
+//  X[i] * A[i] = alpha * B[i],   if tA == blas.NoTrans side == blas.Right,
+//  X[i] * A[i]^T = alpha * B[i], if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+// for 0 <= i < batch, where the A[i] are triangular matrices starting at
+// a[i*strideA:], the X[i] and B[i] are m×n matrices starting at b[i*strideB:],
+// and alpha is a scalar. All of the matrices of the batch have the sizes and
+// strides of a single call to Strsm. A zero strideA uses the same A for every
+// system. strideB must be large enough that the B[i] do not overlap.
+//
+// At entry to the function, X[i] contains the values of B[i], and the result
+// is stored in place into X[i]. The systems are shared between the workers
+// of impl, and each of them is solved on a single goroutine.
+//
+// No check is made that the A[i] are invertible.
+func (impl Implementation) StrsmStridedBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, batch int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	if lda < max(1, k) {
+		panic(badLdA)
+	}
+	if ldb < max(1, n) {
+		panic(badLdB)
+	}
+	if batch < 0 {
+		panic(batchLT0)
+	}
+	if strideA < 0 || strideB < 0 {
+		panic(badBatchStride)
+	}
+	if batch == 0 || m == 0 || n == 0 {
+		return
+	}
+	if (batch-1)*strideA+lda*(k-1)+k > len(a) {
+		panic(batchEntry(badLdA, batch-1))
+	}
+	if (batch-1)*strideB+ldb*(m-1)+n > len(b) {
+		panic(batchEntry(badLdB, batch-1))
+	}
+	if batch > 1 && strideB < ldb*(m-1)+n {
+		panic(badBatchOverlap)
+	}
+
+	nWorkers := impl.batchWorkers(batch * m * n * k)
+	parallelRange(nWorkers, batch, func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			bi := b[i*strideB:]
+			if alpha == 0 {
+				sscaleBlock(m, n, 0, bi, ldb)
+				continue
+			}
+			strsmSerial(s, ul, tA, d, m, n, alpha, a[i*strideA:], lda, bi, ldb)
+		}
+	})
+}
+
+// strsmSerial solves the triangular system described by Strsm on the
+// calling goroutine.
+func strsmSerial(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -194,7 +408,7 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 			return
 		}
 		for i := 0; i < m; i++ {
-			btmp := b[i*lda : i*lda+n]
+			btmp := b[i*ldb : i*ldb+n]
 			if alpha != 1 {
 				for j := 0; j < n; j++ {
 					btmp[j] *= alpha
@@ -214,7 +428,7 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	// Cases where a is transposed.
 	if ul == blas.Upper {
 		for i := 0; i < m; i++ {
-			btmp := b[i*lda : i*lda+n]
+			btmp := b[i*ldb : i*ldb+n]
 			for j := n - 1; j >= 0; j-- {
 				tmp := alpha*btmp[j] - f32.DotUnitary(a[j*lda+j+1:j*lda+n], btmp[j+1:])
 				if nonUnit {
@@ -226,7 +440,7 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 	for i := 0; i < m; i++ {
-		btmp := b[i*lda : i*lda+n]
+		btmp := b[i*ldb : i*ldb+n]
 		for j := 0; j < n; j++ {
 			tmp := alpha*btmp[j] - f32.DotUnitary(a[j*lda:j*lda+j], btmp)
 			if nonUnit {
@@ -242,8 +456,6 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 //  C = alpha * B * A + beta * C, if side == blas.Right,
 // where A is an n×n or m×m symmetric matrix, B and C are m×n matrices, and alpha
 // is a scalar.
-//
-// Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if s != blas.Right && s != blas.Left {
 		panic("goblas: bad side")
@@ -303,9 +515,15 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 			atmp := alpha * a[i*lda+i]
 			btmp := b[i*ldb : i*ldb+n]
 			ctmp := c[i*ldc : i*ldc+n]
-			for j, v := range btmp {
-				ctmp[j] *= beta
-				ctmp[j] += atmp * v
+			if beta == 0 {
+				for j, v := range btmp {
+					ctmp[j] = atmp * v
+				}
+			} else {
+				for j, v := range btmp {
+					ctmp[j] *= beta
+					ctmp[j] += atmp * v
+				}
 			}
 
 			for k := 0; k < i; k++ {
@@ -345,7 +563,11 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 					ctmp[k] += tmp * v
 					tmp2 += btmp[k] * v
 				}
-				c[i*ldc+j] *= beta
+				if beta == 0 {
+					c[i*ldc+j] = 0
+				} else {
+					c[i*ldc+j] *= beta
+				}
 				c[i*ldc+j] += tmp*a[j*lda+j] + alpha*tmp2
 			}
 		}
@@ -362,7 +584,11 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 				ctmp[k] += tmp * v
 				tmp2 += btmp[k] * v
 			}
-			c[i*ldc+j] *= beta
+			if beta == 0 {
+				c[i*ldc+j] = 0
+			} else {
+				c[i*ldc+j] *= beta
+			}
 			c[i*ldc+j] += tmp*a[j*lda+j] + alpha*tmp2
 		}
 	}
@@ -372,9 +598,7 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 //  C = alpha * A * A^T + beta*C
 // C is an n×n symmetric matrix. A is an n×k matrix if tA == blas.NoTrans, and
 // a k×n matrix otherwise. alpha and beta are scalars.
-//
-// Float32 implementations are autogenerated and not directly tested.
-func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+func (impl Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
 	if ul != blas.Lower && ul != blas.Upper {
 		panic(badUplo)
 	}
@@ -402,7 +626,7 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
 		panic(badLdC)
 	}
-	if alpha == 0 {
+	if alpha == 0 || k == 0 {
 		if beta == 0 {
 			if ul == blas.Upper {
 				for i := 0; i < n; i++ {
@@ -438,6 +662,30 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	// Compute C in blocks. The diagonal blocks are computed by the serial
+	// code and the off-diagonal blocks are general matrix products.
+	impl.parallelTriBlocks(ul == blas.Upper, n, func(i, j, leni, lenj int) {
+		if i == j {
+			if tA == blas.NoTrans {
+				ssyrkSerial(ul, tA, leni, k, alpha, a[i*lda:], lda, beta, c[i*ldc+i:], ldc)
+			} else {
+				ssyrkSerial(ul, tA, leni, k, alpha, a[i:], lda, beta, c[i*ldc+i:], ldc)
+			}
+			return
+		}
+		cSub := sliceView32(c, ldc, i, j, leni, lenj)
+		sscaleBlock(leni, lenj, beta, cSub, ldc)
+		if tA == blas.NoTrans {
+			sgemmSerial(false, true, leni, lenj, k, a[i*lda:], lda, a[j*lda:], lda, cSub, ldc, alpha)
+		} else {
+			sgemmSerial(true, false, leni, lenj, k, a[i:], lda, a[j:], lda, cSub, ldc, alpha)
+		}
+	})
+}
+
+// ssyrkSerial computes C = alpha * A * A^T + beta * C or
+// C = alpha * A^T * A + beta * C on the calling goroutine.
+func ssyrkSerial(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -445,7 +693,11 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 				atmp := a[i*lda : i*lda+k]
 				for jc, vc := range ctmp {
 					j := jc + i
-					ctmp[jc] = vc*beta + alpha*f32.DotUnitary(atmp, a[j*lda:j*lda+k])
+					if beta == 0 {
+						ctmp[jc] = alpha * f32.DotUnitary(atmp, a[j*lda:j*lda+k])
+					} else {
+						ctmp[jc] = vc*beta + alpha*f32.DotUnitary(atmp, a[j*lda:j*lda+k])
+					}
 				}
 			}
 			return
@@ -453,7 +705,11 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		for i := 0; i < n; i++ {
 			atmp := a[i*lda : i*lda+k]
 			for j, vc := range c[i*ldc : i*ldc+i+1] {
-				c[i*ldc+j] = vc*beta + alpha*f32.DotUnitary(a[j*lda:j*lda+k], atmp)
+				if beta == 0 {
+					c[i*ldc+j] = alpha * f32.DotUnitary(a[j*lda:j*lda+k], atmp)
+				} else {
+					c[i*ldc+j] = vc*beta + alpha*f32.DotUnitary(a[j*lda:j*lda+k], atmp)
+				}
 			}
 		}
 		return
@@ -462,7 +718,11 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 	if ul == blas.Upper {
 		for i := 0; i < n; i++ {
 			ctmp := c[i*ldc+i : i*ldc+n]
-			if beta != 1 {
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else if beta != 1 {
 				for j := range ctmp {
 					ctmp[j] *= beta
 				}
@@ -478,7 +738,11 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 	}
 	for i := 0; i < n; i++ {
 		ctmp := c[i*ldc : i*ldc+i+1]
-		if beta != 0 {
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
 			for j := range ctmp {
 				ctmp[j] *= beta
 			}
@@ -496,9 +760,7 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 //  C = alpha * A * B^T + alpha * B * A^T + beta * C
 // where C is an n×n symmetric matrix. A and B are n×k matrices if
 // tA == NoTrans and k×n otherwise. alpha and beta are scalars.
-//
-// Float32 implementations are autogenerated and not directly tested.
-func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+func (impl Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if ul != blas.Lower && ul != blas.Upper {
 		panic(badUplo)
 	}
@@ -529,7 +791,7 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 	if ldc*(n-1)+n > len(c) || ldc < max(1, n) {
 		panic(badLdC)
 	}
-	if alpha == 0 {
+	if alpha == 0 || k == 0 {
 		if beta == 0 {
 			if ul == blas.Upper {
 				for i := 0; i < n; i++ {
@@ -565,6 +827,32 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 		}
 		return
 	}
+	// Compute C in blocks. The diagonal blocks are computed by the serial
+	// code and the off-diagonal blocks are sums of two general matrix products.
+	impl.parallelTriBlocks(ul == blas.Upper, n, func(i, j, leni, lenj int) {
+		if i == j {
+			if tA == blas.NoTrans {
+				ssyr2kSerial(ul, tA, leni, k, alpha, a[i*lda:], lda, b[i*ldb:], ldb, beta, c[i*ldc+i:], ldc)
+			} else {
+				ssyr2kSerial(ul, tA, leni, k, alpha, a[i:], lda, b[i:], ldb, beta, c[i*ldc+i:], ldc)
+			}
+			return
+		}
+		cSub := sliceView32(c, ldc, i, j, leni, lenj)
+		sscaleBlock(leni, lenj, beta, cSub, ldc)
+		if tA == blas.NoTrans {
+			sgemmSerial(false, true, leni, lenj, k, a[i*lda:], lda, b[j*ldb:], ldb, cSub, ldc, alpha)
+			sgemmSerial(false, true, leni, lenj, k, b[i*ldb:], ldb, a[j*lda:], lda, cSub, ldc, alpha)
+		} else {
+			sgemmSerial(true, false, leni, lenj, k, a[i:], lda, b[j:], ldb, cSub, ldc, alpha)
+			sgemmSerial(true, false, leni, lenj, k, b[i:], ldb, a[j:], lda, cSub, ldc, alpha)
+		}
+	})
+}
+
+// ssyr2kSerial computes C = alpha * A * B^T + alpha * B * A^T + beta * C or
+// C = alpha * A^T * B + alpha * B^T * A + beta * C on the calling goroutine.
+func ssyr2kSerial(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -579,7 +867,11 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 						tmp1 += v * btmp[l]
 						tmp2 += atmp[l] * binner[l]
 					}
-					ctmp[jc] *= beta
+					if beta == 0 {
+						ctmp[jc] = 0
+					} else {
+						ctmp[jc] *= beta
+					}
 					ctmp[jc] += alpha * (tmp1 + tmp2)
 				}
 			}
@@ -596,7 +888,11 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 					tmp1 += v * btmp[l]
 					tmp2 += atmp[l] * binner[l]
 				}
-				ctmp[j] *= beta
+				if beta == 0 {
+					ctmp[j] = 0
+				} else {
+					ctmp[j] *= beta
+				}
 				ctmp[j] += alpha * (tmp1 + tmp2)
 			}
 		}
@@ -605,13 +901,17 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 	if ul == blas.Upper {
 		for i := 0; i < n; i++ {
 			ctmp := c[i*ldc+i : i*ldc+n]
-			if beta != 1 {
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			} else if beta != 1 {
 				for j := range ctmp {
 					ctmp[j] *= beta
 				}
 			}
 			for l := 0; l < k; l++ {
-				tmp1 := alpha * b[l*lda+i]
+				tmp1 := alpha * b[l*ldb+i]
 				tmp2 := alpha * a[l*lda+i]
 				btmp := b[l*ldb+i : l*ldb+n]
 				if tmp1 != 0 || tmp2 != 0 {
@@ -625,13 +925,17 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 	}
 	for i := 0; i < n; i++ {
 		ctmp := c[i*ldc : i*ldc+i+1]
-		if beta != 1 {
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+		} else if beta != 1 {
 			for j := range ctmp {
 				ctmp[j] *= beta
 			}
 		}
 		for l := 0; l < k; l++ {
-			tmp1 := alpha * b[l*lda+i]
+			tmp1 := alpha * b[l*ldb+i]
 			tmp2 := alpha * a[l*lda+i]
 			btmp := b[l*ldb : l*ldb+i+1]
 			if tmp1 != 0 || tmp2 != 0 {
@@ -649,9 +953,7 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 //  B = alpha * B * A,   if tA == blas.NoTrans and side == blas.Right,
 //  B = alpha * B * A^T, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
 // where A is an n×n or m×m triangular matrix, and B is an m×n matrix.
-//
-// Float32 implementations are autogenerated and not directly tested.
-func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+func (impl Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	if s != blas.Left && s != blas.Right {
 		panic(badSide)
 	}
@@ -682,6 +984,9 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 	if ldb*(m-1)+n > len(b) || ldb < max(1, n) {
 		panic(badLdB)
 	}
+	if m == 0 || n == 0 {
+		return
+	}
 	if alpha == 0 {
 		for i := 0; i < m; i++ {
 			btmp := b[i*ldb : i*ldb+n]
@@ -692,6 +997,72 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		return
 	}
 
+	bs := impl.blockSize()
+	if k <= bs {
+		impl.parallelRHS(s, m, n, func(i, j, r, c int) {
+			strmmSerial(s, ul, tA, d, r, c, alpha, a, lda, b[i*ldb+j:], ldb)
+		})
+		return
+	}
+
+	// Multiply by bs×bs diagonal blocks of A, with the right-hand sides of
+	// each diagonal block computed concurrently. The blocks are visited in
+	// an order such that the contribution of the off-diagonal blocks can
+	// then be added using Sgemm from parts of B that are not yet updated.
+	upper := ul == blas.Upper
+	trans := tA != blas.NoTrans
+	if s == blas.Left {
+		forward := upper != trans
+		for bi := 0; bi < blocks(m, bs); bi++ {
+			i0 := bi * bs
+			if !forward {
+				i0 = (blocks(m, bs) - 1 - bi) * bs
+			}
+			size := min(bs, m-i0)
+			i1 := i0 + size
+			impl.parallelRHS(s, size, n, func(i, j, r, c int) {
+				strmmSerial(s, ul, tA, d, r, c, alpha, a[i0*lda+i0:], lda, b[(i0+i)*ldb+j:], ldb)
+			})
+			switch {
+			case !trans && upper && i1 < m:
+				impl.sgemmParallel(false, false, size, n, m-i1, a[i0*lda+i1:], lda, b[i1*ldb:], ldb, b[i0*ldb:], ldb, alpha)
+			case !trans && !upper && i0 > 0:
+				impl.sgemmParallel(false, false, size, n, i0, a[i0*lda:], lda, b, ldb, b[i0*ldb:], ldb, alpha)
+			case trans && upper && i0 > 0:
+				impl.sgemmParallel(true, false, size, n, i0, a[i0:], lda, b, ldb, b[i0*ldb:], ldb, alpha)
+			case trans && !upper && i1 < m:
+				impl.sgemmParallel(true, false, size, n, m-i1, a[i1*lda+i0:], lda, b[i1*ldb:], ldb, b[i0*ldb:], ldb, alpha)
+			}
+		}
+		return
+	}
+	forward := upper == trans
+	for bj := 0; bj < blocks(n, bs); bj++ {
+		j0 := bj * bs
+		if !forward {
+			j0 = (blocks(n, bs) - 1 - bj) * bs
+		}
+		size := min(bs, n-j0)
+		j1 := j0 + size
+		impl.parallelRHS(s, m, size, func(i, j, r, c int) {
+			strmmSerial(s, ul, tA, d, r, c, alpha, a[j0*lda+j0:], lda, b[i*ldb+j0+j:], ldb)
+		})
+		switch {
+		case !trans && upper && j0 > 0:
+			impl.sgemmParallel(false, false, m, size, j0, b, ldb, a[j0:], lda, b[j0:], ldb, alpha)
+		case !trans && !upper && j1 < n:
+			impl.sgemmParallel(false, false, m, size, n-j1, b[j1:], ldb, a[j1*lda+j0:], lda, b[j0:], ldb, alpha)
+		case trans && upper && j1 < n:
+			impl.sgemmParallel(false, true, m, size, n-j1, b[j1:], ldb, a[j0*lda+j1:], lda, b[j0:], ldb, alpha)
+		case trans && !upper && j0 > 0:
+			impl.sgemmParallel(false, true, m, size, j0, b, ldb, a[j0*lda:], lda, b[j0:], ldb, alpha)
+		}
+	}
+}
+
+// strmmSerial computes the triangular matrix product described by Strmm on
+// the calling goroutine.
+func strmmSerial(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -841,3 +1212,22 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 	}
 }
+
+// sscaleBlock computes C = beta * C for an m×n matrix C.
+func sscaleBlock(m, n int, beta float32, c []float32, ldc int) {
+	if beta == 1 {
+		return
+	}
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+			continue
+		}
+		for j := range ctmp {
+			ctmp[j] *= beta
+		}
+	}
+}
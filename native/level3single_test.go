@@ -0,0 +1,35 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/testblas"
+)
+
+func TestSgemm(t *testing.T) {
+	testblas.SgemmTest(t, impl)
+}
+
+func TestSsymm(t *testing.T) {
+	testblas.SsymmTest(t, impl)
+}
+
+func TestSsyrk(t *testing.T) {
+	testblas.SsyrkTest(t, impl)
+}
+
+func TestSsyr2k(t *testing.T) {
+	testblas.Ssyr2kTest(t, impl)
+}
+
+func TestStrmm(t *testing.T) {
+	testblas.StrmmTest(t, impl)
+}
+
+func TestStrsm(t *testing.T) {
+	testblas.StrsmTest(t, impl)
+}
@@ -6,7 +6,58 @@
 
 package native
 
-type Implementation struct{}
+import (
+	"fmt"
+	"runtime"
+)
+
+// Implementation is the native Go implementation of the BLAS routines. The
+// zero value is ready to use, and runs the parallel Level 3 routines on up to
+// GOMAXPROCS goroutines with the default block size.
+type Implementation struct {
+	// MaxWorkers is the maximum number of goroutines used by a single call
+	// to a parallel routine. If MaxWorkers is zero or negative,
+	// runtime.GOMAXPROCS(0) is used.
+	MaxWorkers int
+
+	// BlockSize is the size of the square blocks that [SDCZ]gemm partition
+	// C into when the matrices are too small for packing. For larger
+	// matrices, [SD]gemm and Dsgemm pack A in BlockSize×BlockSize blocks,
+	// with the rows rounded up to a multiple of 4. If BlockSize is zero or
+	// negative, the unpacked blocks are 64×64 and the packed blocks of A
+	// are 64×128.
+	BlockSize int
+
+	// Serial specifies that all routines run on the calling goroutine only.
+	Serial bool
+
+	// Reproducible specifies that Ddot, Dasum, Dnrm2 and Dgemv compute their
+	// sums so that the results are bitwise identical for the same values,
+	// whatever the vector increments, the alignment of the data, the number
+	// of goroutines and the build tags. The sums are formed by pre-rounding
+	// the terms, which is slower than the default summation but usually
+	// more accurate.
+	Reproducible bool
+}
+
+// maxWorkers returns the number of goroutines a single call may use.
+func (impl Implementation) maxWorkers() int {
+	if impl.Serial {
+		return 1
+	}
+	if impl.MaxWorkers > 0 {
+		return impl.MaxWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// blockSize returns the block size used by the unpacked [SDCZ]gemm.
+func (impl Implementation) blockSize() int {
+	if impl.BlockSize > 0 {
+		return impl.BlockSize
+	}
+	return blockSize
+}
 
 // The following are panic strings used during parameter checks.
 const (
@@ -33,24 +84,56 @@ const (
 
 	badX = "blas: x index out of range"
 	badY = "blas: y index out of range"
+
+	batchLT0        = "blas: batch < 0"
+	badBatchLen     = "blas: mismatched batch lengths"
+	badBatchStride  = "blas: negative batch stride"
+	badBatchOverlap = "blas: overlapping matrices in batch"
 )
 
+// batchEntry returns the panic string for the failure of the check with
+// panic string msg on entry i of a batch.
+func batchEntry(msg string, i int) string {
+	return fmt.Sprintf("%s in batch entry %d", msg, i)
+}
+
 // [SD]gemm behavior constants. These are kept here to keep them out of the
 // way during single precision code genration.
 const (
-	blockSize   = 64 // b x b matrix
+	blockSize   = 64 // default b x b matrix
 	minParBlock = 4  // minimum number of blocks needed to go parallel
-	buffMul     = 4  // how big is the buffer relative to the number of workers
+
+	// Blocking of the packed [SD]gemm. An mcBlock×kcBlock packed block of A
+	// is multiplied by a kcBlock×ncBlock packed block of B, updating mrBlock
+	// rows of C at a time. The sizes are chosen so that the packed block of A
+	// stays in L2 cache and mrBlock rows of the C block stay in L1 cache.
+	// Below minPackDim the cost of packing outweighs the gain, and the
+	// unpacked blocked code is as fast or faster.
+	mcBlock    = 64
+	kcBlock    = 128
+	ncBlock    = 512
+	mrBlock    = 4   // rows of C updated together by the kernel
+	minPackDim = 256 // minimum dimension of all of m, n and k to use packing
+
+	// minMixedPackDim is the minimum of the largest of m, n and k to use
+	// packing in Dsgemm and Sdsgemm, where packing also converts A and B to
+	// double precision.
+	minMixedPackDim = 16
+
+	minSplitK = 1024 // minimum length of the k range computed by each split-k worker
+)
+
+// Level 2 and batched routine behavior constants.
+const (
+	minParLevel2 = 1 << 16 // minimum number of matrix elements needed to go parallel
+	level2Chunks = 4       // ranges of rows or columns given to each worker
+
+	minParBatch = 1 << 16 // minimum number of multiply-adds in a batch needed to go parallel
 )
 
 // [SD]gemm debugging constant.
 const debug = false
 
-// subMul is a common type shared by [SD]gemm.
-type subMul struct {
-	i, j int // index of block
-}
-
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -65,8 +148,44 @@ func min(a, b int) int {
 	return a
 }
 
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
 // blocks returns the number of divisons of the dimension length with the given
 // block size.
 func blocks(dim, bsize int) int {
 	return (dim + bsize - 1) / bsize
 }
+
+// gemmPacking holds the blocking of the packed [SD]gemm. An mc×kc block of
+// A is packed and multiplied by a packed kc×nc block of B.
+type gemmPacking struct {
+	mc, kc, nc int
+}
+
+// packing returns the blocking of the packed [SD]gemm. It is mcBlock×kcBlock
+// and kcBlock×ncBlock by default, and is derived from BlockSize if it is set.
+func (impl Implementation) packing() gemmPacking {
+	if impl.BlockSize <= 0 {
+		return gemmPacking{mc: mcBlock, kc: kcBlock, nc: ncBlock}
+	}
+	mc := blocks(impl.BlockSize, mrBlock) * mrBlock
+	return gemmPacking{mc: mc, kc: impl.BlockSize, nc: max(ncBlock, mc)}
+}
+
+// packedBlocks returns the size of the blocks of C that are shared between
+// the workers of a packed m×n [SD]gemm. The blocks are mc×nc, but are
+// narrowed by halving their width, while it stays at least mc, until there
+// are at least as many of them as workers.
+func (impl Implementation) packedBlocks(m, n int) (bm, bn int) {
+	pack := impl.packing()
+	bm, bn = pack.mc, pack.nc
+	for bn/2 >= bm && blocks(m, bm)*blocks(n, bn) < impl.maxWorkers() {
+		bn /= 2
+	}
+	return bm, bn
+}
@@ -116,15 +116,97 @@ func TestDgemmParallel(t *testing.T) {
 			tA:    blas.NoTrans,
 			tB:    blas.NoTrans,
 		},
+		{
+			m:     mcBlock*minParBlock + mrBlock + 1,
+			n:     ncBlock + 5,
+			k:     kcBlock*2 + 7,
+			alpha: 2.5,
+			tA:    blas.NoTrans,
+			tB:    blas.NoTrans,
+		},
+		{
+			m:     minPackDim + 3,
+			n:     minPackDim,
+			k:     minPackDim + 1,
+			alpha: 2.5,
+			tA:    blas.NoTrans,
+			tB:    blas.NoTrans,
+		},
+	} {
+		testMatchParallelSerial(t, Implementation{}, i, blas.NoTrans, blas.NoTrans, test.m, test.n, test.k, test.alpha, 1e-12)
+		testMatchParallelSerial(t, Implementation{}, i, blas.Trans, blas.NoTrans, test.m, test.n, test.k, test.alpha, 1e-12)
+		testMatchParallelSerial(t, Implementation{}, i, blas.NoTrans, blas.Trans, test.m, test.n, test.k, test.alpha, 1e-12)
+		testMatchParallelSerial(t, Implementation{}, i, blas.Trans, blas.Trans, test.m, test.n, test.k, test.alpha, 1e-12)
+	}
+}
+
+func TestDgemmConfig(t *testing.T) {
+	for _, impl := range []Implementation{
+		{Serial: true},
+		{MaxWorkers: 1},
+		{MaxWorkers: 3},
+		{BlockSize: 7},
+		{BlockSize: 100, MaxWorkers: 2},
+		{BlockSize: -1, MaxWorkers: -1},
+	} {
+		for i, test := range []struct {
+			m, n, k int
+		}{
+			{3, 4, 2},
+			{blockSize*2 + 5, blockSize + 3, 17},
+			{5, 3, 2*minSplitK + 3},
+			{minPackDim + 1, minPackDim, minPackDim + 2},
+		} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					testMatchParallelSerial(t, impl, i, tA, tB, test.m, test.n, test.k, 2.5, 1e-9)
+				}
+			}
+		}
+	}
+}
+
+func TestPackedBlocks(t *testing.T) {
+	for _, test := range []struct {
+		m, n    int
+		workers int
+	}{
+		{500, 500, 1},
+		{500, 500, 8},
+		{500, 500, 64},
+		{500, 500, 1000},
+		{minPackDim, 4000, 16},
+		{4000, minPackDim, 16},
+	} {
+		m, n := test.m, test.n
+		bm, bn := Implementation{MaxWorkers: test.workers}.packedBlocks(m, n)
+		if bm != mcBlock || bn < mcBlock || bn > ncBlock {
+			t.Errorf("m=%v,n=%v,workers=%v: unexpected block size %v×%v", m, n, test.workers, bm, bn)
+			continue
+		}
+		want := min(test.workers, blocks(m, mcBlock)*blocks(n, mcBlock))
+		if got := blocks(m, bm) * blocks(n, bn); got < want {
+			t.Errorf("m=%v,n=%v,workers=%v: too few blocks: got %v, want at least %v", m, n, test.workers, got, want)
+		}
+	}
+	if _, bn := (Implementation{Serial: true}).packedBlocks(500, 500); bn != ncBlock {
+		t.Errorf("unexpected serial block width: got %v, want %v", bn, ncBlock)
+	}
+	for _, test := range []struct {
+		bs   int
+		want gemmPacking
+	}{
+		{0, gemmPacking{mc: mcBlock, kc: kcBlock, nc: ncBlock}},
+		{30, gemmPacking{mc: 32, kc: 30, nc: ncBlock}},
+		{1000, gemmPacking{mc: 1000, kc: 1000, nc: 1000}},
 	} {
-		testMatchParallelSerial(t, i, blas.NoTrans, blas.NoTrans, test.m, test.n, test.k, test.alpha)
-		testMatchParallelSerial(t, i, blas.Trans, blas.NoTrans, test.m, test.n, test.k, test.alpha)
-		testMatchParallelSerial(t, i, blas.NoTrans, blas.Trans, test.m, test.n, test.k, test.alpha)
-		testMatchParallelSerial(t, i, blas.Trans, blas.Trans, test.m, test.n, test.k, test.alpha)
+		if got := (Implementation{BlockSize: test.bs}).packing(); got != test.want {
+			t.Errorf("BlockSize=%v: unexpected packing: got %+v, want %+v", test.bs, got, test.want)
+		}
 	}
 }
 
-func testMatchParallelSerial(t *testing.T, i int, tA, tB blas.Transpose, m, n, k int, alpha float64) {
+func testMatchParallelSerial(t *testing.T, impl Implementation, i int, tA, tB blas.Transpose, m, n, k int, alpha, tol float64) {
 	var (
 		rowA, colA int
 		rowB, colB int
@@ -155,15 +237,15 @@ func testMatchParallelSerial(t *testing.T, i int, tA, tB blas.Transpose, m, n, k
 	ldb := colB
 	ldc := n
 	dgemmSerial(tA == blas.Trans, tB == blas.Trans, m, n, k, a.data, lda, b.data, ldb, cClone.data, ldc, alpha)
-	dgemmParallel(tA == blas.Trans, tB == blas.Trans, m, n, k, a.data, lda, b.data, ldb, c.data, ldc, alpha)
+	impl.dgemmParallel(tA == blas.Trans, tB == blas.Trans, m, n, k, a.data, lda, b.data, ldb, c.data, ldc, alpha)
 	if !a.equal(aClone) {
 		t.Errorf("Case %v: a changed during call to dgemmParallel", i)
 	}
 	if !b.equal(bClone) {
 		t.Errorf("Case %v: b changed during call to dgemmParallel", i)
 	}
-	if !c.equalWithinAbs(cClone, 1e-12) {
-		t.Errorf("Case %v: answer not equal parallel and serial", i)
+	if !c.equalWithinAbs(cClone, tol) {
+		t.Errorf("Case %v, %+v: answer not equal parallel and serial", i, impl)
 	}
 }
 
@@ -179,3 +261,55 @@ func randmat(r, c, stride int) general64 {
 		stride: stride,
 	}
 }
+
+func TestDgemmSplitK(t *testing.T) {
+	for i, test := range []struct {
+		m, n, k  int
+		nWorkers int
+		packed   bool
+	}{
+		{m: 3, n: 4, k: 2 * minSplitK, nWorkers: 2},
+		{m: blockSize + 1, n: 5, k: 3*minSplitK + 7, nWorkers: 3},
+		{m: 7, n: blockSize - 1, k: 4*minSplitK - 1, nWorkers: 4},
+		{m: minPackDim, n: minPackDim + 3, k: 2*minSplitK + 5, nWorkers: 2, packed: true},
+	} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				testSplitK(t, i, tA, tB, test.m, test.n, test.k, test.nWorkers, test.packed)
+			}
+		}
+	}
+}
+
+func testSplitK(t *testing.T, i int, tA, tB blas.Transpose, m, n, k, nWorkers int, packed bool) {
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	a := randmat(rowA, colA, colA)
+	b := randmat(rowB, colB, colB)
+	c := randmat(m, n, n)
+
+	aTrans := tA == blas.Trans
+	bTrans := tB == blas.Trans
+	want := c.clone()
+	dgemmSerial(aTrans, bTrans, m, n, k, a.data, colA, b.data, colB, want.data, n, 2.5)
+
+	got := c.clone()
+	dgemmSplitK(nWorkers, blockSize, Implementation{}.packing(), packed, aTrans, bTrans, m, n, k, a.data, colA, b.data, colB, got.data, n, 2.5)
+	if !got.equalWithinAbs(want, 1e-9) {
+		t.Errorf("Case %v, tA=%v, tB=%v: answer not equal split-k and serial", i, tA, tB)
+	}
+	for r := 0; r < 3; r++ {
+		again := c.clone()
+		dgemmSplitK(nWorkers, blockSize, Implementation{}.packing(), packed, aTrans, bTrans, m, n, k, a.data, colA, b.data, colB, again.data, n, 2.5)
+		if !again.equal(got) {
+			t.Errorf("Case %v, tA=%v, tB=%v: split-k result not reproducible", i, tA, tB)
+			break
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+var level2Tests = []struct {
+	m, n int
+}{
+	{3, 5},
+	{300, 400},
+	{17, 5000},
+	{5000, 17},
+	{513, 513},
+}
+
+var level2Incs = []struct {
+	x, y int
+}{
+	{1, 1},
+	{2, -3},
+	{-1, 2},
+}
+
+var level2Impls = []Implementation{{MaxWorkers: 3}, {MaxWorkers: 64}, {}}
+
+func randvec(n, inc int) []float64 {
+	if inc < 0 {
+		inc = -inc
+	}
+	v := make([]float64, (n-1)*inc+1)
+	for i := range v {
+		v[i] = rand.NormFloat64()
+	}
+	return v
+}
+
+func TestDgemvParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range level2Tests {
+		m, n := test.m, test.n
+		a := randmat(m, n, n+1).data
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA == blas.Trans {
+				lenX, lenY = m, n
+			}
+			for _, inc := range level2Incs {
+				prefix := fmt.Sprintf("m=%v,n=%v,tA=%v,incX=%v,incY=%v", m, n, tA, inc.x, inc.y)
+				x := randvec(lenX, inc.x)
+				y := randvec(lenY, inc.y)
+				want := make([]float64, len(y))
+				copy(want, y)
+				Implementation{Serial: true}.Dgemv(tA, m, n, 1.5, a, n+1, x, inc.x, 0.5, want, inc.y)
+				for _, impl := range level2Impls {
+					got := make([]float64, len(y))
+					copy(got, y)
+					impl.Dgemv(tA, m, n, 1.5, a, n+1, x, inc.x, 0.5, got, inc.y)
+					if !sameFloat64s(got, want) {
+						t.Errorf("%v, %+v: result differs from serial", prefix, impl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDgerParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range level2Tests {
+		m, n := test.m, test.n
+		for _, inc := range level2Incs {
+			prefix := fmt.Sprintf("m=%v,n=%v,incX=%v,incY=%v", m, n, inc.x, inc.y)
+			x := randvec(m, inc.x)
+			y := randvec(n, inc.y)
+			a := randmat(m, n, n+1).data
+			want := make([]float64, len(a))
+			copy(want, a)
+			Implementation{Serial: true}.Dger(m, n, 1.5, x, inc.x, y, inc.y, want, n+1)
+			for _, impl := range level2Impls {
+				got := make([]float64, len(a))
+				copy(got, a)
+				impl.Dger(m, n, 1.5, x, inc.x, y, inc.y, got, n+1)
+				if !sameFloat64s(got, want) {
+					t.Errorf("%v, %+v: result differs from serial", prefix, impl)
+				}
+			}
+		}
+	}
+}
+
+func TestDsymvParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, n := range []int{1, 7, 300, 1001} {
+		a := randmat(n, n, n+1).data
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, inc := range level2Incs {
+				prefix := fmt.Sprintf("n=%v,ul=%v,incX=%v,incY=%v", n, ul, inc.x, inc.y)
+				x := randvec(n, inc.x)
+				y := randvec(n, inc.y)
+				want := make([]float64, len(y))
+				copy(want, y)
+				Implementation{Serial: true}.Dsymv(ul, n, 1.5, a, n+1, x, inc.x, 0.5, want, inc.y)
+				for _, impl := range level2Impls {
+					got := make([]float64, len(y))
+					copy(got, y)
+					impl.Dsymv(ul, n, 1.5, a, n+1, x, inc.x, 0.5, got, inc.y)
+					if !closeFloat64s(got, want, 1e-12) {
+						t.Errorf("%v, %+v: unexpected result", prefix, impl)
+					}
+					again := make([]float64, len(y))
+					copy(again, y)
+					impl.Dsymv(ul, n, 1.5, a, n+1, x, inc.x, 0.5, again, inc.y)
+					if !sameFloat64s(got, again) {
+						t.Errorf("%v, %+v: result not reproducible", prefix, impl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func benchmarkDgemvParallel(b *testing.B, tA blas.Transpose, n int) {
+	a := randmat(n, n, n).data
+	x := randvec(n, 1)
+	y := randvec(n, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Implementation{}.Dgemv(tA, n, n, 1, a, n, x, 1, 0, y, 1)
+	}
+}
+
+func BenchmarkDgemvParallelNoTrans2000(b *testing.B) { benchmarkDgemvParallel(b, blas.NoTrans, 2000) }
+func BenchmarkDgemvParallelTrans2000(b *testing.B)   { benchmarkDgemvParallel(b, blas.Trans, 2000) }
@@ -0,0 +1,125 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+var rankKTests = []struct {
+	n, k int
+}{
+	{1, 3},
+	{blockSize - 1, 5},
+	{blockSize + 1, 1},
+	{2*blockSize + 3, 17},
+	{3*blockSize + 5, 70},
+}
+
+func TestDsyrkParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range rankKTests {
+		n, k := test.n, test.k
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float64{0, 1, 0.5} {
+					prefix := fmt.Sprintf("n=%v,k=%v,ul=%v,tA=%v,beta=%v", n, k, ul, tA, beta)
+					a := randmat(n, k, k).data
+					lda := k
+					if tA == blas.Trans {
+						a = randmat(k, n, n).data
+						lda = n
+					}
+					c := randmat(n, n, n).data
+
+					want := make([]float64, len(c))
+					copy(want, c)
+					dsyrkSerial(ul, tA, n, k, 1.5, a, lda, beta, want, n)
+
+					for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {}} {
+						got := make([]float64, len(c))
+						copy(got, c)
+						impl.Dsyrk(ul, tA, n, k, 1.5, a, lda, beta, got, n)
+						if !sameFloat64s(got, want) {
+							t.Errorf("%v, %+v: result differs from serial", prefix, impl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDsyr2kParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range rankKTests {
+		n, k := test.n, test.k
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float64{0, 1, 0.5} {
+					prefix := fmt.Sprintf("n=%v,k=%v,ul=%v,tA=%v,beta=%v", n, k, ul, tA, beta)
+					row, col := n, k
+					if tA == blas.Trans {
+						row, col = k, n
+					}
+					a := randmat(row, col, col).data
+					b := randmat(row, col, col).data
+					c := randmat(n, n, n).data
+
+					want := make([]float64, len(c))
+					copy(want, c)
+					dsyr2kSerial(ul, tA, n, k, 1.5, a, col, b, col, beta, want, n)
+
+					serial := make([]float64, len(c))
+					copy(serial, c)
+					Implementation{Serial: true}.Dsyr2k(ul, tA, n, k, 1.5, a, col, b, col, beta, serial, n)
+					if !closeFloat64s(serial, want, 1e-12) {
+						t.Errorf("%v: unexpected result", prefix)
+					}
+
+					for _, impl := range []Implementation{{MaxWorkers: 3}, {}} {
+						got := make([]float64, len(c))
+						copy(got, c)
+						impl.Dsyr2k(ul, tA, n, k, 1.5, a, col, b, col, beta, got, n)
+						if !sameFloat64s(got, serial) {
+							t.Errorf("%v, %+v: result differs from serial", prefix, impl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func sameFloat64s(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.Float64bits(v) != math.Float64bits(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func closeFloat64s(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.Abs(v-b[i]) > tol*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+var triangularTests = []struct {
+	m, n int
+}{
+	{1, 1},
+	{blockSize - 1, 3},
+	{3, blockSize + 1},
+	{blockSize + 1, 5*blockSize + 2},
+	{2*blockSize + 3, 17},
+	{5*blockSize + 7, 3*blockSize + 5},
+}
+
+// randtri returns a random n×n matrix with small off-diagonal elements and
+// a unit-sized diagonal, so that both of its triangles are well conditioned
+// whether or not the diagonal is assumed to be unit.
+func randtri(n int) []float64 {
+	a := randmat(n, n, n).data
+	for i := range a {
+		a[i] /= float64(n)
+	}
+	for i := 0; i < n; i++ {
+		a[i*n+i] += 1
+	}
+	return a
+}
+
+func testTriangular(t *testing.T, name string, fn func(impl Implementation, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int), serial func(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int)) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	for _, test := range triangularTests {
+		m, n := test.m, test.n
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			k := m
+			if s == blas.Right {
+				k = n
+			}
+			a := randtri(k)
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						prefix := fmt.Sprintf("%v: m=%v,n=%v,s=%v,ul=%v,tA=%v,d=%v", name, m, n, s, ul, tA, d)
+						ldb := n + 3
+						b := randmat(m, n, ldb).data
+						want := make([]float64, len(b))
+						copy(want, b)
+						serial(s, ul, tA, d, m, n, 1.5, a, k, want, ldb)
+
+						for _, impl := range []Implementation{{Serial: true}, {MaxWorkers: 3}, {BlockSize: 7}, {}} {
+							got := make([]float64, len(b))
+							copy(got, b)
+							fn(impl, s, ul, tA, d, m, n, 1.5, a, k, got, ldb)
+							if !closeFloat64s(got, want, 1e-10) {
+								t.Errorf("%v, %+v: result differs from serial", prefix, impl)
+							}
+							for i := 0; i < m; i++ {
+								for j := n; j < ldb && i*ldb+j < len(b); j++ {
+									if got[i*ldb+j] != b[i*ldb+j] {
+										t.Errorf("%v, %+v: element outside B modified", prefix, impl)
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDtrsmParallel(t *testing.T) {
+	testTriangular(t, "Dtrsm", Implementation.Dtrsm, dtrsmSerial)
+}
+
+func TestDtrmmParallel(t *testing.T) {
+	testTriangular(t, "Dtrmm", Implementation.Dtrmm, dtrmmSerial)
+}
@@ -0,0 +1,234 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gonum/blas"
+)
+
+// workers is the pool of goroutines shared by the parallel routines of
+// the package.
+var workers pool
+
+// pool is a set of long-lived goroutines that execute work on behalf of
+// the parallel routines. Reusing the goroutines avoids starting new ones
+// on every call. The zero value is ready to use; the goroutines are
+// started by the first call to parallel.
+type pool struct {
+	mu    sync.Mutex
+	tasks chan func()
+	done  *sync.WaitGroup
+}
+
+// parallel calls fn concurrently from at most n goroutines and returns when
+// all of the calls have returned. One of the calls is made by the calling
+// goroutine and the others by idle workers of the pool, so fewer than n
+// calls may be made when the pool is busy. fn must therefore share the work
+// between however many calls are made, for example by taking work items
+// from a counter, and must not wait for the other calls.
+func (p *pool) parallel(n int, fn func()) {
+	if n <= 1 {
+		fn()
+		return
+	}
+	var wg sync.WaitGroup
+	task := func() {
+		defer wg.Done()
+		fn()
+	}
+	p.mu.Lock()
+	if p.tasks == nil {
+		p.start()
+	}
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		sent := true
+		select {
+		case p.tasks <- task:
+		default:
+			sent = false
+		}
+		if !sent {
+			wg.Done()
+			break
+		}
+	}
+	p.mu.Unlock()
+	fn()
+	wg.Wait()
+}
+
+// start launches GOMAXPROCS workers. p.mu must be held.
+func (p *pool) start() {
+	n := runtime.GOMAXPROCS(0)
+	tasks := make(chan func())
+	done := &sync.WaitGroup{}
+	done.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer done.Done()
+			for task := range tasks {
+				task()
+			}
+		}()
+	}
+	p.tasks = tasks
+	p.done = done
+}
+
+// stop stops the workers and waits for them to finish the work they have
+// already been given.
+func (p *pool) stop() {
+	p.mu.Lock()
+	if p.tasks == nil {
+		p.mu.Unlock()
+		return
+	}
+	close(p.tasks)
+	done := p.done
+	p.tasks = nil
+	p.done = nil
+	p.mu.Unlock()
+	done.Wait()
+}
+
+// StopWorkers stops the goroutines used by the parallel routines in this
+// package and waits for them to exit once their current work is complete.
+// The goroutines are started again by the next parallel call, so StopWorkers
+// is only needed when a program must release all of its goroutines, for
+// example before checking for goroutine leaks.
+func StopWorkers() {
+	workers.stop()
+}
+
+// parallelTriBlocks calls fn for each block of the upper or lower triangle,
+// including the diagonal, of an n×n matrix partitioned into square blocks
+// of impl's block size. i and j are the first row and column of the block,
+// and leni and lenj are its dimensions. The calls are shared between up to
+// impl.maxWorkers() goroutines, so fn must be safe to call concurrently for
+// different blocks.
+func (impl Implementation) parallelTriBlocks(upper bool, n int, fn func(i, j, leni, lenj int)) {
+	bs := impl.blockSize()
+	nb := blocks(n, bs)
+	nBlocks := nb * (nb + 1) / 2
+	nWorkers := impl.maxWorkers()
+	if nBlocks < minParBlock {
+		nWorkers = 1
+	} else if nBlocks < nWorkers {
+		nWorkers = nBlocks
+	}
+	var next int64
+	workers.parallel(nWorkers, func() {
+		for {
+			t := int(atomic.AddInt64(&next, 1) - 1)
+			if t >= nBlocks {
+				return
+			}
+			// Blocks are numbered by rows of the lower triangle.
+			bi := 0
+			for (bi+1)*(bi+2)/2 <= t {
+				bi++
+			}
+			bj := t - bi*(bi+1)/2
+			if upper {
+				bi, bj = bj, bi
+			}
+			i := bi * bs
+			j := bj * bs
+			fn(i, j, min(bs, n-i), min(bs, n-j))
+		}
+	})
+}
+
+// parallelRHS calls fn for chunks of the right-hand sides of an m×n matrix B
+// that is multiplied or solved by a triangular matrix from side s. The
+// right-hand sides are the columns of B if s is blas.Left and the rows of B
+// otherwise. i and j are the first row and column of the chunk, and r and c
+// are its dimensions. The calls are shared between up to impl.maxWorkers()
+// goroutines.
+func (impl Implementation) parallelRHS(s blas.Side, m, n int, fn func(i, j, r, c int)) {
+	bs := impl.blockSize()
+	nRHS := n
+	if s == blas.Right {
+		nRHS = m
+	}
+	nChunks := blocks(nRHS, bs)
+	nWorkers := impl.maxWorkers()
+	if nChunks < minParBlock {
+		nWorkers = 1
+	} else if nChunks < nWorkers {
+		nWorkers = nChunks
+	}
+	var next int64
+	workers.parallel(nWorkers, func() {
+		for {
+			t := int(atomic.AddInt64(&next, 1) - 1)
+			if t >= nChunks {
+				return
+			}
+			l := t * bs
+			if s == blas.Left {
+				fn(0, l, m, min(bs, n-l))
+			} else {
+				fn(l, 0, min(bs, m-l), n)
+			}
+		}
+	})
+}
+
+// level2Workers returns the number of goroutines used by a Level 2 call that
+// touches work matrix elements. Calls that are too small to benefit from the
+// parallel routines run on a single goroutine.
+func (impl Implementation) level2Workers(work int) int {
+	if work < minParLevel2 {
+		return 1
+	}
+	return impl.maxWorkers()
+}
+
+// batchWorkers returns the number of goroutines used by a batched call
+// that performs work multiply-adds in total.
+func (impl Implementation) batchWorkers(work int) int {
+	if work < minParBatch {
+		return 1
+	}
+	return impl.maxWorkers()
+}
+
+// parallelFor calls fn(t) for each 0 <= t < n, sharing the calls between up
+// to nWorkers goroutines.
+func parallelFor(nWorkers, n int, fn func(t int)) {
+	if n < nWorkers {
+		nWorkers = n
+	}
+	var next int64
+	workers.parallel(nWorkers, func() {
+		for {
+			t := int(atomic.AddInt64(&next, 1) - 1)
+			if t >= n {
+				return
+			}
+			fn(t)
+		}
+	})
+}
+
+// parallelRange splits [0, n) into contiguous ranges, level2Chunks for each
+// worker, and calls fn(i0, i1) for each range [i0, i1), sharing the calls
+// between up to nWorkers goroutines.
+func parallelRange(nWorkers, n int, fn func(i0, i1 int)) {
+	if n == 0 {
+		return
+	}
+	size := blocks(n, min(n, level2Chunks*nWorkers))
+	parallelFor(nWorkers, blocks(n, size), func(t int) {
+		i0 := t * size
+		fn(i0, min(i0+size, n))
+	})
+}
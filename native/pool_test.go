@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPoolParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	var p pool
+	defer p.stop()
+	for _, n := range []int{0, 1, 2, 4, 16} {
+		for _, items := range []int{0, 1, 3, 100} {
+			done := make([]int64, items)
+			var next int64
+			p.parallel(n, func() {
+				for {
+					i := int(atomic.AddInt64(&next, 1) - 1)
+					if i >= items {
+						return
+					}
+					atomic.AddInt64(&done[i], 1)
+				}
+			})
+			for i, v := range done {
+				if v != 1 {
+					t.Errorf("n=%d, items=%d: item %d done %d times", n, items, i, v)
+				}
+			}
+		}
+	}
+}
+
+func TestPoolNested(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+	var p pool
+	defer p.stop()
+	var count int64
+	p.parallel(4, func() {
+		p.parallel(4, func() {
+			atomic.AddInt64(&count, 1)
+		})
+	})
+	if count == 0 {
+		t.Error("nested parallel call did no work")
+	}
+}
+
+func TestPoolStop(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	var p pool
+	p.stop()
+	for i := 0; i < 3; i++ {
+		var count int64
+		p.parallel(4, func() {
+			atomic.AddInt64(&count, 1)
+		})
+		if count < 1 {
+			t.Errorf("run %d: no work done", i)
+		}
+		p.stop()
+		if p.tasks != nil {
+			t.Errorf("run %d: pool not stopped", i)
+		}
+	}
+}
+
+func BenchmarkPoolParallel(b *testing.B) {
+	n := runtime.GOMAXPROCS(0)
+	var p pool
+	defer p.stop()
+	for i := 0; i < b.N; i++ {
+		var next int64
+		p.parallel(n, func() {
+			for atomic.AddInt64(&next, 1) <= minParBlock {
+			}
+		})
+	}
+}
+
+// BenchmarkGoroutineParallel measures the per-call overhead of starting
+// workers and passing them work over a channel, which the pool replaces.
+func BenchmarkGoroutineParallel(b *testing.B) {
+	n := runtime.GOMAXPROCS(0)
+	for i := 0; i < b.N; i++ {
+		work := make(chan int, minParBlock)
+		var wg sync.WaitGroup
+		for w := 0; w < n; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range work {
+				}
+			}()
+		}
+		for j := 0; j < minParBlock; j++ {
+			work <- j
+		}
+		close(work)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// Reproducible summation
+//
+// The sums of Implementation's reproducible mode are computed by pre-rounding,
+// following Demmel and Nguyen, "Fast Reproducible Floating-Point Summation",
+// ARITH 21, 2013. Given an upper bound M on the magnitude of the n terms of a
+// sum, each term v is split as
+//  q = (σ + v) - σ,  v = v - q
+// where σ is a power of two no smaller than 2nM. Every q is a multiple of
+// ulp(σ)/2 and the sum of the q is smaller than σ, so the q are summed
+// exactly, and therefore in any order. The remainders v are then split in the
+// same way against a smaller σ, for reproFolds folds in total. Combining the
+// exact sums of the folds in a fixed order gives a result that depends only
+// on the values of the terms. Each fold reduces the magnitude of the
+// remainders by a factor of about 2^52/n, and the remainders left after the
+// last fold are dropped.
+//
+// All products that form the terms are converted explicitly to float64 so
+// that they are rounded the same way on architectures with fused
+// multiply-add instructions.
+
+const (
+	reproFolds = 3 // number of folds used by rsum
+
+	// The terms are scaled by a power of two so that the first σ is
+	// 2^sigmaExp. This keeps all of the σ in the normal range without
+	// risk of overflow.
+	sigmaExp = 1000
+)
+
+// rsum is a reproducible accumulator for a sum of n terms with magnitudes no
+// larger than max.
+type rsum struct {
+	scale int // binary exponent by which the terms are scaled down
+	sigma [reproFolds]float64
+	t     [reproFolds]float64
+
+	// If max is not finite, the terms are summed in the order they are
+	// added, which gives a reproducible infinity or NaN.
+	naive    bool
+	naiveSum float64
+
+	zero bool // all of the terms are zero
+}
+
+// init prepares r for the summation of n terms with magnitudes no larger than
+// max, which may be NaN or infinite.
+func (r *rsum) init(n int, max float64) {
+	*r = rsum{}
+	if math.IsNaN(max) || math.IsInf(max, 0) {
+		r.naive = true
+		return
+	}
+	if max == 0 {
+		r.zero = true
+		return
+	}
+	// 2^ln > n and 2^e > max, so the first σ = 2^(e+ln+1) > 2nM.
+	_, e := math.Frexp(max)
+	ln := 0
+	for 1<<uint(ln) <= n {
+		ln++
+	}
+	r.scale = e + ln + 1 - sigmaExp
+	exp := sigmaExp
+	for k := range r.sigma {
+		r.sigma[k] = math.Ldexp(1, exp)
+		// The remainders are bounded by ulp(σ)/2 = 2^(exp-53).
+		exp += ln + 1 - 53
+	}
+}
+
+// add adds the term v to the sum.
+func (r *rsum) add(v float64) {
+	if r.naive {
+		r.naiveSum += v
+		return
+	}
+	if r.zero {
+		return
+	}
+	v = math.Ldexp(v, -r.scale)
+	for k, s := range r.sigma {
+		q := float64(s+v) - s
+		r.t[k] += q
+		v -= q
+	}
+}
+
+// sum returns the sum of the terms.
+func (r *rsum) sum() float64 {
+	if r.naive {
+		return r.naiveSum
+	}
+	var sum float64
+	for k := len(r.t) - 1; k >= 0; k-- {
+		sum += r.t[k]
+	}
+	return math.Ldexp(sum, r.scale)
+}
+
+// absMax returns the larger of m and |v|, or NaN if either is NaN.
+func absMax(m, v float64) float64 {
+	v = math.Abs(v)
+	if v > m || v != v {
+		return v
+	}
+	return m
+}
+
+// reproducibleDot returns the dot product of the n elements of x and y
+// starting at x[kx] and y[ky] with increments incX and incY.
+func reproducibleDot(n int, x []float64, incX, kx int, y []float64, incY, ky int) float64 {
+	var max float64
+	ix, iy := kx, ky
+	for i := 0; i < n; i++ {
+		max = absMax(max, float64(x[ix]*y[iy]))
+		ix += incX
+		iy += incY
+	}
+	var r rsum
+	r.init(n, max)
+	ix, iy = kx, ky
+	for i := 0; i < n; i++ {
+		r.add(float64(x[ix] * y[iy]))
+		ix += incX
+		iy += incY
+	}
+	return r.sum()
+}
+
+// reproducibleAsum returns the sum of the absolute values of the n elements
+// of x with increment incX.
+func reproducibleAsum(n int, x []float64, incX int) float64 {
+	var max float64
+	for i := 0; i < n; i++ {
+		max = absMax(max, x[i*incX])
+	}
+	var r rsum
+	r.init(n, max)
+	for i := 0; i < n; i++ {
+		r.add(math.Abs(x[i*incX]))
+	}
+	return r.sum()
+}
+
+// reproducibleNrm2 returns the Euclidean norm of the n elements of x with
+// increment incX. The elements are scaled by a power of two close to their
+// largest magnitude before they are squared, so the scaling is exact.
+func reproducibleNrm2(n int, x []float64, incX int) float64 {
+	var max float64
+	for i := 0; i < n; i++ {
+		max = absMax(max, x[i*incX])
+	}
+	if math.IsNaN(max) {
+		return math.NaN()
+	}
+	if math.IsInf(max, 1) {
+		return math.Inf(1)
+	}
+	if max == 0 {
+		return 0
+	}
+	_, e := math.Frexp(max)
+	var r rsum
+	r.init(n, 1)
+	for i := 0; i < n; i++ {
+		v := math.Ldexp(x[i*incX], -e)
+		r.add(float64(v * v))
+	}
+	return math.Ldexp(math.Sqrt(r.sum()), e)
+}
+
+// dgemvReproducible computes
+//  y += alpha * A * x    if tA == blas.NoTrans
+//  y += alpha * A^T * x  if tA == blas.Trans or blas.ConjTrans
+// for the m×n matrix A using reproducible sums. kx and ky are the indices of
+// the first elements of x and y. Each element of y is computed by a single
+// goroutine, so the result does not depend on the number of workers.
+func dgemvReproducible(impl Implementation, tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
+	nWorkers := impl.level2Workers(m * n)
+	if tA == blas.NoTrans {
+		parallelRange(nWorkers, m, func(i0, i1 int) {
+			dgemvReproducibleRows(i0, i1, n, alpha, a, lda, x, incX, kx, y, incY, ky)
+		})
+		return
+	}
+	parallelRange(nWorkers, n, func(j0, j1 int) {
+		dgemvReproducibleCols(j0, j1, m, alpha, a, lda, x, incX, kx, y, incY, ky)
+	})
+}
+
+// dgemvReproducibleRows computes
+//  y[i] += alpha * A[i, :] * x
+// for the rows i0 <= i < i1 of the m×n matrix A using reproducible sums.
+// kx and ky are the indices of the first elements of x and y.
+func dgemvReproducibleRows(i0, i1, n int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
+	iy := ky + i0*incY
+	for i := i0; i < i1; i++ {
+		y[iy] += float64(alpha * reproducibleDot(n, a[lda*i:lda*i+n], 1, 0, x, incX, kx))
+		iy += incY
+	}
+}
+
+// dgemvReproducibleCols computes
+//  y[j] += alpha * A[:, j]^T * x
+// for the columns j0 <= j < j1 of the m×n matrix A using reproducible sums.
+// The rows of A are traversed in order, accumulating the sums for all of the
+// columns at once. kx and ky are the indices of the first elements of x and
+// y.
+func dgemvReproducibleCols(j0, j1, m int, alpha float64, a []float64, lda int, x []float64, incX, kx int, y []float64, incY, ky int) {
+	max := make([]float64, j1-j0)
+	ix := kx
+	for i := 0; i < m; i++ {
+		xi := x[ix]
+		for j, v := range a[lda*i+j0 : lda*i+j1] {
+			max[j] = absMax(max[j], float64(v*xi))
+		}
+		ix += incX
+	}
+	r := make([]rsum, j1-j0)
+	for j := range r {
+		r[j].init(m, max[j])
+	}
+	ix = kx
+	for i := 0; i < m; i++ {
+		xi := x[ix]
+		for j, v := range a[lda*i+j0 : lda*i+j1] {
+			r[j].add(float64(v * xi))
+		}
+		ix += incX
+	}
+	jy := ky + j0*incY
+	for j := range r {
+		y[jy] += float64(alpha * r[j].sum())
+		jy += incY
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/testblas"
+)
+
+var reproducible = Implementation{Reproducible: true}
+
+func TestReproducibleLevel1(t *testing.T) {
+	testblas.DasumTest(t, reproducible)
+	testblas.DdotTest(t, reproducible)
+	testblas.Dnrm2Test(t, reproducible)
+}
+
+func TestReproducibleLevel2(t *testing.T) {
+	testblas.DgemvTest(t, reproducible)
+}
+
+// wideVec returns a vector of n values with magnitudes spread over about
+// 2^-spread to 2^spread.
+func wideVec(rnd *rand.Rand, n, spread int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = math.Ldexp(rnd.NormFloat64(), rnd.Intn(2*spread+1)-spread)
+	}
+	return v
+}
+
+// strided returns a slice holding v with increment inc, starting at offset
+// so that the data is not aligned as v is. A negative inc stores v in
+// reverse as the BLAS convention requires.
+func strided(v []float64, inc, offset int) []float64 {
+	n := len(v)
+	if n == 0 {
+		return make([]float64, offset)
+	}
+	abs := inc
+	if abs < 0 {
+		abs = -abs
+	}
+	s := make([]float64, offset+(n-1)*abs+1)
+	for i := range s {
+		s[i] = math.NaN()
+	}
+	k := 0
+	if inc < 0 {
+		k = (n - 1) * abs
+	}
+	for _, x := range v {
+		s[offset+k] = x
+		k += inc
+	}
+	return s[offset:]
+}
+
+func permuted(rnd *rand.Rand, x, y []float64) (px, py []float64) {
+	px = make([]float64, len(x))
+	py = make([]float64, len(y))
+	for i, j := range rnd.Perm(len(x)) {
+		px[i] = x[j]
+		py[i] = y[j]
+	}
+	return px, py
+}
+
+func exactDot(x, y []float64) float64 {
+	sum := new(big.Float).SetPrec(2048)
+	for i := range x {
+		p := new(big.Float).SetPrec(2048).SetFloat64(x[i])
+		p.Mul(p, new(big.Float).SetFloat64(y[i]))
+		sum.Add(sum, p)
+	}
+	f, _ := sum.Float64()
+	return f
+}
+
+func TestReproducibleDdot(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impl := reproducible
+	for _, n := range []int{1, 2, 3, 7, 100, 1001} {
+		for _, spread := range []int{0, 20, 200} {
+			x := wideVec(rnd, n, spread)
+			y := wideVec(rnd, n, spread)
+			want := impl.Ddot(n, x, 1, y, 1)
+
+			var abs float64
+			for i := range x {
+				abs += math.Abs(x[i] * y[i])
+			}
+			if exact := exactDot(x, y); math.Abs(want-exact) > 1e-15*abs {
+				t.Errorf("n=%v,spread=%v: inaccurate result: got %v, want %v", n, spread, want, exact)
+			}
+
+			for _, inc := range []struct{ x, y int }{{1, 1}, {2, 3}, {-1, 1}, {-3, -2}} {
+				for offset := 0; offset < 3; offset++ {
+					got := impl.Ddot(n, strided(x, inc.x, offset), inc.x, strided(y, inc.y, offset+1), inc.y)
+					if math.Float64bits(got) != math.Float64bits(want) {
+						t.Errorf("n=%v,spread=%v,incX=%v,incY=%v,offset=%v: result differs: got %v, want %v", n, spread, inc.x, inc.y, offset, got, want)
+					}
+				}
+			}
+			for i := 0; i < 5; i++ {
+				px, py := permuted(rnd, x, y)
+				got := impl.Ddot(n, px, 1, py, 1)
+				if math.Float64bits(got) != math.Float64bits(want) {
+					t.Errorf("n=%v,spread=%v: result of permuted terms differs: got %v, want %v", n, spread, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestReproducibleDdotSpecial(t *testing.T) {
+	impl := reproducible
+	inf := math.Inf(1)
+	tiny := math.SmallestNonzeroFloat64
+	for i, test := range []struct {
+		x, y []float64
+		want float64
+	}{
+		{x: []float64{0, 0, 0}, y: []float64{1, 2, 3}, want: 0},
+		{x: []float64{1e308, 1e308, -1e308}, y: []float64{1, 1, 1}, want: 1e308},
+		{x: []float64{1, inf, 3}, y: []float64{1, 1, 1}, want: inf},
+		{x: []float64{1, inf, 3}, y: []float64{1, 1, -inf}, want: math.NaN()},
+		{x: []float64{1, math.NaN(), 3}, y: []float64{1, 1, 1}, want: math.NaN()},
+		{x: []float64{tiny, tiny, -3 * tiny}, y: []float64{1, 1, 1}, want: -tiny},
+		{x: []float64{1e-300, 1e-300}, y: []float64{1e-300, -1e-300}, want: 0},
+	} {
+		got := impl.Ddot(len(test.x), test.x, 1, test.y, 1)
+		if got != test.want && !(math.IsNaN(got) && math.IsNaN(test.want)) {
+			t.Errorf("case %d: unexpected result: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestReproducibleDasumDnrm2(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	impl := reproducible
+	for _, n := range []int{1, 2, 3, 7, 100, 1001} {
+		for _, spread := range []int{0, 20, 200} {
+			x := wideVec(rnd, n, spread)
+			asum := impl.Dasum(n, x, 1)
+			nrm2 := impl.Dnrm2(n, x, 1)
+			if want := (Implementation{}).Dasum(n, x, 1); math.Abs(asum-want) > 1e-14*want {
+				t.Errorf("n=%v,spread=%v: unexpected Dasum: got %v, want %v", n, spread, asum, want)
+			}
+			if want := (Implementation{}).Dnrm2(n, x, 1); math.Abs(nrm2-want) > 1e-14*want {
+				t.Errorf("n=%v,spread=%v: unexpected Dnrm2: got %v, want %v", n, spread, nrm2, want)
+			}
+			for _, inc := range []int{1, 2, 5} {
+				for offset := 0; offset < 3; offset++ {
+					sx := strided(x, inc, offset)
+					if got := impl.Dasum(n, sx, inc); math.Float64bits(got) != math.Float64bits(asum) {
+						t.Errorf("n=%v,spread=%v,inc=%v,offset=%v: Dasum differs", n, spread, inc, offset)
+					}
+					if got := impl.Dnrm2(n, sx, inc); math.Float64bits(got) != math.Float64bits(nrm2) {
+						t.Errorf("n=%v,spread=%v,inc=%v,offset=%v: Dnrm2 differs", n, spread, inc, offset)
+					}
+				}
+			}
+			px, _ := permuted(rnd, x, x)
+			if got := impl.Dasum(n, px, 1); math.Float64bits(got) != math.Float64bits(asum) {
+				t.Errorf("n=%v,spread=%v: Dasum of permuted terms differs", n, spread)
+			}
+			if got := impl.Dnrm2(n, px, 1); math.Float64bits(got) != math.Float64bits(nrm2) {
+				t.Errorf("n=%v,spread=%v: Dnrm2 of permuted terms differs", n, spread)
+			}
+		}
+	}
+}
+
+func TestReproducibleDgemv(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n int }{{1, 1}, {3, 5}, {37, 29}, {300, 400}, {5000, 17}} {
+		m, n := test.m, test.n
+		a := wideVec(rnd, m*n, 30)
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			lenX, lenY := n, m
+			if tA == blas.Trans {
+				lenX, lenY = m, n
+			}
+			x := wideVec(rnd, lenX, 30)
+
+			// Each element of y must match the reproducible dot product.
+			want := make([]float64, lenY)
+			for i := range want {
+				if tA == blas.NoTrans {
+					want[i] = reproducible.Ddot(n, a[i*n:], 1, x, 1)
+				} else {
+					want[i] = reproducible.Ddot(m, a[i:], n, x, 1)
+				}
+			}
+
+			for _, impl := range []Implementation{{Reproducible: true, Serial: true}, {Reproducible: true, MaxWorkers: 3}, {Reproducible: true}} {
+				for _, inc := range level2Incs {
+					prefix := fmt.Sprintf("m=%v,n=%v,tA=%v,incX=%v,incY=%v,%+v", m, n, tA, inc.x, inc.y, impl)
+					lda := n + 1
+					sa := make([]float64, m*lda)
+					for i := 0; i < m; i++ {
+						copy(sa[i*lda:], a[i*n:(i+1)*n])
+					}
+					y := strided(make([]float64, lenY), inc.y, 0)
+					impl.Dgemv(tA, m, n, 1, sa, lda, strided(x, inc.x, 1), inc.x, 0, y, inc.y)
+					k := 0
+					if inc.y < 0 {
+						k = (lenY - 1) * -inc.y
+					}
+					for i, w := range want {
+						if math.Float64bits(y[k]) != math.Float64bits(w) {
+							t.Errorf("%v: element %d differs: got %v, want %v", prefix, i, y[k], w)
+							break
+						}
+						k += inc.y
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestReproducibleDgemvBatched(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	rnd := rand.New(rand.NewSource(1))
+	const m, n, batch = 37, 300, 10
+	a := wideVec(rnd, batch*m*n, 30)
+	x := wideVec(rnd, batch*n, 30)
+	want := make([]float64, batch*m)
+	for b := 0; b < batch; b++ {
+		for i := 0; i < m; i++ {
+			want[b*m+i] = reproducible.Ddot(n, a[b*m*n+i*n:], 1, x[b*n:], 1)
+		}
+	}
+	for _, impl := range []Implementation{{Reproducible: true, Serial: true}, {Reproducible: true, MaxWorkers: 3}, {Reproducible: true}} {
+		as := make([][]float64, batch)
+		xs := make([][]float64, batch)
+		ys := make([][]float64, batch)
+		for b := range ys {
+			as[b] = a[b*m*n : (b+1)*m*n]
+			xs[b] = x[b*n : (b+1)*n]
+			ys[b] = make([]float64, m)
+		}
+		impl.DgemvBatched(blas.NoTrans, m, n, 1, as, n, xs, 1, 0, ys, 1)
+		y := make([]float64, batch*m)
+		impl.DgemvStridedBatched(blas.NoTrans, m, n, 1, a, n, m*n, x, 1, n, 0, y, 1, m, batch)
+		for b := 0; b < batch; b++ {
+			for i := 0; i < m; i++ {
+				w := want[b*m+i]
+				if math.Float64bits(ys[b][i]) != math.Float64bits(w) {
+					t.Errorf("%+v: DgemvBatched entry %d element %d differs: got %v, want %v", impl, b, i, ys[b][i], w)
+				}
+				if math.Float64bits(y[b*m+i]) != math.Float64bits(w) {
+					t.Errorf("%+v: DgemvStridedBatched entry %d element %d differs: got %v, want %v", impl, b, i, y[b*m+i], w)
+				}
+			}
+		}
+	}
+}
@@ -7,8 +7,7 @@
 package native
 
 import (
-	"runtime"
-	"sync"
+	"sync/atomic"
 
 	"github.com/gonum/blas"
 	"github.com/gonum/internal/asm/f32"
@@ -18,9 +17,7 @@ import (
 //  C = beta * C + alpha * A * B,
 // where A, B, and C are dense matrices, and alpha and beta are scalars.
 // tA and tB specify whether A or B are transposed.
-//
-// Float32 implementations are autogenerated and not directly tested.
-func (Implementation) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+func (impl Implementation) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
 		panic(badTranspose)
 	}
@@ -41,6 +38,11 @@ func (Implementation) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a
 	}
 	checkMatrix32(m, n, c, ldc)
 
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
 	// scale c
 	if beta != 1 {
 		if beta == 0 {
@@ -60,10 +62,124 @@ func (Implementation) Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a
 		}
 	}
 
-	sgemmParallel(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+	impl.sgemmParallel(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+}
+
+// SgemmBatched computes
+//  C[i] = beta * C[i] + alpha * A[i] * B[i],
+// for each product i in the batch, where the A[i], B[i] and C[i] are dense
+// matrices held in a[i], b[i] and c[i], and alpha and beta are scalars. All
+// of the matrices of the batch have the sizes and strides of a single call
+// to Sgemm, and tA and tB specify whether the A[i] or B[i] are transposed.
+// a, b and c must have the same length, and the C[i] must not overlap.
+//
+// The products are shared between the workers of impl, and each of them is
+// computed on a single goroutine, which suits batches of many small
+// matrices.
+func (impl Implementation) SgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float32, a [][]float32, lda int, b [][]float32, ldb int, beta float32, c [][]float32, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if len(a) != len(c) || len(b) != len(c) {
+		panic(badBatchLen)
+	}
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	bTrans := tB == blas.Trans || tB == blas.ConjTrans
+	for i := range c {
+		if aTrans {
+			checkBatchMatrix32(i, k, m, a[i], lda)
+		} else {
+			checkBatchMatrix32(i, m, k, a[i], lda)
+		}
+		if bTrans {
+			checkBatchMatrix32(i, n, k, b[i], ldb)
+		} else {
+			checkBatchMatrix32(i, k, n, b[i], ldb)
+		}
+		checkBatchMatrix32(i, m, n, c[i], ldc)
+	}
+
+	// Quick return if possible
+	if m == 0 || n == 0 || len(c) == 0 {
+		return
+	}
+
+	nWorkers := impl.batchWorkers(len(c) * m * n * max(k, 1))
+	parallelRange(nWorkers, len(c), func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			sscaleBlock(m, n, beta, c[i], ldc)
+			if alpha != 0 {
+				sgemmSerial(aTrans, bTrans, m, n, k, a[i], lda, b[i], ldb, c[i], ldc, alpha)
+			}
+		}
+	})
 }
 
-func sgemmParallel(aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, c []float32, ldc int, alpha float32) {
+// SgemmStridedBatched computes
+//  C[i] = beta * C[i] + alpha * A[i] * B[i],
+// for 0 <= i < batch, where the A[i], B[i] and C[i] are dense matrices
+// starting at a[i*strideA:], b[i*strideB:] and c[i*strideC:], and alpha and
+// beta are scalars. All of the matrices of the batch have the sizes and
+// strides of a single call to Sgemm, and tA and tB specify whether the A[i]
+// or B[i] are transposed. A zero strideA or strideB uses the same A or B for
+// every product. strideC must be large enough that the C[i] do not overlap.
+//
+// The products are shared between the workers of impl, and each of them is
+// computed on a single goroutine.
+func (impl Implementation) SgemmStridedBatched(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda, strideA int, b []float32, ldb, strideB int, beta float32, c []float32, ldc, strideC int, batch int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if batch < 0 {
+		panic(batchLT0)
+	}
+	if strideA < 0 || strideB < 0 || strideC < 0 {
+		panic(badBatchStride)
+	}
+	if batch == 0 {
+		return
+	}
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	if aTrans {
+		checkStrided32(k, m, a, lda, strideA, batch)
+	} else {
+		checkStrided32(m, k, a, lda, strideA, batch)
+	}
+	bTrans := tB == blas.Trans || tB == blas.ConjTrans
+	if bTrans {
+		checkStrided32(n, k, b, ldb, strideB, batch)
+	} else {
+		checkStrided32(k, n, b, ldb, strideB, batch)
+	}
+	checkStrided32(m, n, c, ldc, strideC, batch)
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+	if batch > 1 && strideC < (m-1)*ldc+n {
+		panic(badBatchOverlap)
+	}
+
+	nWorkers := impl.batchWorkers(batch * m * n * max(k, 1))
+	parallelRange(nWorkers, batch, func(i0, i1 int) {
+		for i := i0; i < i1; i++ {
+			ci := c[i*strideC:]
+			sscaleBlock(m, n, beta, ci, ldc)
+			if alpha != 0 {
+				sgemmSerial(aTrans, bTrans, m, n, k, a[i*strideA:], lda, b[i*strideB:], ldb, ci, ldc, alpha)
+			}
+		}
+	})
+}
+
+func (impl Implementation) sgemmParallel(aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, c []float32, ldc int, alpha float32) {
 	// dgemmParallel computes a parallel matrix multiplication by partitioning
 	// a and b into sub-blocks, and updating c with the multiplication of the sub-block
 	// In all cases,
@@ -72,8 +188,8 @@ func sgemmParallel(aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []f
 	//				...
 	//			A_i1	A_i2 ...	A_ij]
 	//
-	// and same for B. All of the submatrix sizes are blockSize×blockSize except
-	// at the edges.
+	// and same for B. All of the submatrix sizes are bs×bs, where bs is the
+	// block size of impl, except at the edges.
 	//
 	// In all cases, there is one dimension for each matrix along which
 	// C must be updated sequentially.
@@ -86,99 +202,297 @@ func sgemmParallel(aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []f
 	// and computes all of the {i, j} blocks concurrently. This
 	// partitioning allows Cij to be updated in-place without race-conditions.
 	// Instead of launching a goroutine for each possible concurrent computation,
-	// a number of worker goroutines are created and channels are used to pass
-	// available and completed cases.
+	// the blocks are shared between a number of workers from the package's
+	// worker pool.
+	//
+	// When all of the dimensions are at least minPackDim, the {i, j} blocks
+	// are instead sized by packedBlocks and are computed by sgemmPacked,
+	// which copies the sub-blocks of A and B into contiguous panels before
+	// multiplying them. Each worker owns its own packing buffers.
 	//
 	// http://alexkr.com/docs/matrixmult.pdf is a good reference on matrix-matrix
-	// multiplies, though this code does not copy matrices to attempt to eliminate
-	// cache misses.
+	// multiplies, and "Anatomy of High-Performance Matrix Multiplication" by
+	// Goto and van de Geijn describes the packed scheme.
 
 	maxKLen := k
-	parBlocks := blocks(m, blockSize) * blocks(n, blockSize)
+	bs := impl.blockSize()
+	packed := m >= minPackDim && n >= minPackDim && k >= minPackDim
+	pack := impl.packing()
+	bm, bn := bs, bs
+	if packed {
+		bm, bn = impl.packedBlocks(m, n)
+	}
+	parBlocks := blocks(m, bm) * blocks(n, bn)
 	if parBlocks < minParBlock {
 		// The matrix multiplication is small in the dimensions where it can be
-		// computed concurrently. Just do it in serial.
+		// computed concurrently. If it is long enough in the k dimension,
+		// split it along k, otherwise just do it in serial.
+		nWorkers := impl.maxWorkers()
+		if k/minSplitK < nWorkers {
+			nWorkers = k / minSplitK
+		}
+		if nWorkers > 1 {
+			sgemmSplitK(nWorkers, bs, pack, packed, aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+			return
+		}
+		if packed {
+			sgemmPacked(pack, aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha, nil, nil)
+			return
+		}
 		sgemmSerial(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
 		return
 	}
 
-	nWorkers := runtime.GOMAXPROCS(0)
+	nWorkers := impl.maxWorkers()
 	if parBlocks < nWorkers {
 		nWorkers = parBlocks
 	}
-	// There is a tradeoff between the workers having to wait for work
-	// and a large buffer making operations slow.
-	buf := buffMul * nWorkers
-	if buf > parBlocks {
-		buf = parBlocks
-	}
-
-	sendChan := make(chan subMul, buf)
-
-	// Launch workers. A worker receives an {i, j} submatrix of c, and computes
-	// A_ik B_ki (or the transposed version) storing the result in c_ij. When the
-	// channel is finally closed, it signals to the waitgroup that it has finished
-	// computing.
-	var wg sync.WaitGroup
-	for i := 0; i < nWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			// Make local copies of otherwise global variables to reduce shared memory.
-			// This has a noticable effect on benchmarks in some cases.
-			alpha := alpha
-			aTrans := aTrans
-			bTrans := bTrans
-			m := m
-			n := n
-			for sub := range sendChan {
-				i := sub.i
-				j := sub.j
-				leni := blockSize
-				if i+leni > m {
-					leni = m - i
+
+	// Run workers from the pool. A worker takes {i, j} submatrices of c in
+	// turn, and computes A_ik B_ki (or the transposed version) storing the
+	// result in c_ij. A worker returns when all of the submatrices have been
+	// taken.
+	nbj := blocks(n, bn)
+	var next int64
+	workers.parallel(nWorkers, func() {
+		// Make local copies of otherwise global variables to reduce shared memory.
+		// This has a noticable effect on benchmarks in some cases.
+		alpha := alpha
+		aTrans := aTrans
+		bTrans := bTrans
+		m := m
+		n := n
+		var aPack, bPack []float32
+		if packed {
+			aPack = make([]float32, pack.mc*pack.kc)
+			bPack = make([]float32, pack.kc*pack.nc)
+		}
+		for {
+			sub := int(atomic.AddInt64(&next, 1) - 1)
+			if sub >= parBlocks {
+				return
+			}
+			i := (sub / nbj) * bm
+			j := (sub % nbj) * bn
+			leni := bm
+			if i+leni > m {
+				leni = m - i
+			}
+			lenj := bn
+			if j+lenj > n {
+				lenj = n - j
+			}
+
+			cSub := sliceView32(c, ldc, i, j, leni, lenj)
+
+			if packed {
+				var aSub, bSub []float32
+				if aTrans {
+					aSub = sliceView32(a, lda, 0, i, maxKLen, leni)
+				} else {
+					aSub = sliceView32(a, lda, i, 0, leni, maxKLen)
+				}
+				if bTrans {
+					bSub = sliceView32(b, ldb, j, 0, lenj, maxKLen)
+				} else {
+					bSub = sliceView32(b, ldb, 0, j, maxKLen, lenj)
+				}
+				sgemmPacked(pack, aTrans, bTrans, leni, lenj, maxKLen, aSub, lda, bSub, ldb, cSub, ldc, alpha, aPack, bPack)
+				continue
+			}
+
+			// Compute A_ik B_kj for all k
+			for k := 0; k < maxKLen; k += bs {
+				lenk := bs
+				if k+lenk > maxKLen {
+					lenk = maxKLen - k
+				}
+				var aSub, bSub []float32
+				if aTrans {
+					aSub = sliceView32(a, lda, k, i, lenk, leni)
+				} else {
+					aSub = sliceView32(a, lda, i, k, leni, lenk)
+				}
+				if bTrans {
+					bSub = sliceView32(b, ldb, j, k, lenj, lenk)
+				} else {
+					bSub = sliceView32(b, ldb, k, j, lenk, lenj)
+				}
+				sgemmSerial(aTrans, bTrans, leni, lenj, lenk, aSub, lda, bSub, ldb, cSub, ldc, alpha)
+			}
+		}
+	})
+}
+
+// sgemmSplitK computes C += alpha * op(A) * op(B) by partitioning the k
+// simension into nWorkers contiguous ranges that are computed concurrently.
+// The partial product of each range is accumulated in its own m×n buffer,
+// and the buffers are then added into C in order so that the result does
+// not depend on goroutine scheduling. Each range is computed with the
+// packing blocks pack if packed is true, and otherwise in blocks of length
+// bs.
+func sgemmSplitK(nWorkers, bs int, pack gemmPacking, packed, aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, c []float32, ldc int, alpha float32) {
+	work := make([]float32, nWorkers*m*n)
+	var next int64
+	workers.parallel(nWorkers, func() {
+		for {
+			w := int(atomic.AddInt64(&next, 1) - 1)
+			if w >= nWorkers {
+				return
+			}
+			k0 := w * k / nWorkers
+			k1 := (w + 1) * k / nWorkers
+			buf := work[w*m*n : (w+1)*m*n]
+			if packed {
+				var aSub, bSub []float32
+				if aTrans {
+					aSub = sliceView32(a, lda, k0, 0, k1-k0, m)
+				} else {
+					aSub = sliceView32(a, lda, 0, k0, m, k1-k0)
 				}
-				lenj := blockSize
-				if j+lenj > n {
-					lenj = n - j
+				if bTrans {
+					bSub = sliceView32(b, ldb, 0, k0, n, k1-k0)
+				} else {
+					bSub = sliceView32(b, ldb, k0, 0, k1-k0, n)
 				}
+				sgemmPacked(pack, aTrans, bTrans, m, n, k1-k0, aSub, lda, bSub, ldb, buf, n, alpha, nil, nil)
+				continue
+			}
+			for l := k0; l < k1; l += bs {
+				lenk := bs
+				if l+lenk > k1 {
+					lenk = k1 - l
+				}
+				var aSub, bSub []float32
+				if aTrans {
+					aSub = sliceView32(a, lda, l, 0, lenk, m)
+				} else {
+					aSub = sliceView32(a, lda, 0, l, m, lenk)
+				}
+				if bTrans {
+					bSub = sliceView32(b, ldb, 0, l, n, lenk)
+				} else {
+					bSub = sliceView32(b, ldb, l, 0, lenk, n)
+				}
+				sgemmSerial(aTrans, bTrans, m, n, lenk, aSub, lda, bSub, ldb, buf, n, alpha)
+			}
+		}
+	})
+
+	// Reduce the partial products into c.
+	for w := 0; w < nWorkers; w++ {
+		buf := work[w*m*n : (w+1)*m*n]
+		for i := 0; i < m; i++ {
+			f32.AxpyUnitary(1, buf[i*n:i*n+n], c[i*ldc:i*ldc+n])
+		}
+	}
+}
+
+// sgemmPacked computes C += alpha * op(A) * op(B) by copying pack.kc deep
+// panels of op(A) and op(B) into aPack and bPack and multiplying the packed
+// panels with sgemmKernel. The packed panel of A holds a pack.mc×pack.kc
+// block and is reused for every row of the pack.nc wide packed panel of B.
+// aPack and bPack are allocated if they are nil.
+func sgemmPacked(pack gemmPacking, aTrans, bTrans bool, m, n, k int, a []float32, lda int, b []float32, ldb int, c []float32, ldc int, alpha float32, aPack, bPack []float32) {
+	if aPack == nil {
+		aPack = make([]float32, pack.mc*pack.kc)
+	}
+	if bPack == nil {
+		bPack = make([]float32, pack.kc*pack.nc)
+	}
+	for jc := 0; jc < n; jc += pack.nc {
+		nc := min(pack.nc, n-jc)
+		for pc := 0; pc < k; pc += pack.kc {
+			kc := min(pack.kc, k-pc)
+			sgemmPackB(bTrans, kc, nc, b, ldb, pc, jc, bPack)
+			for ic := 0; ic < m; ic += pack.mc {
+				mc := min(pack.mc, m-ic)
+				sgemmPackA(aTrans, mc, kc, a, lda, ic, pc, alpha, aPack)
+				sgemmKernel(mc, nc, kc, aPack, bPack, c[ic*ldc+jc:], ldc)
+			}
+		}
+	}
+}
 
-				cSub := sliceView32(c, ldc, i, j, leni, lenj)
-
-				// Compute A_ik B_kj for all k
-				for k := 0; k < maxKLen; k += blockSize {
-					lenk := blockSize
-					if k+lenk > maxKLen {
-						lenk = maxKLen - k
-					}
-					var aSub, bSub []float32
-					if aTrans {
-						aSub = sliceView32(a, lda, k, i, lenk, leni)
-					} else {
-						aSub = sliceView32(a, lda, i, k, leni, lenk)
-					}
-					if bTrans {
-						bSub = sliceView32(b, ldb, j, k, lenj, lenk)
-					} else {
-						bSub = sliceView32(b, ldb, k, j, lenk, lenj)
-					}
-					sgemmSerial(aTrans, bTrans, leni, lenj, lenk, aSub, lda, bSub, ldb, cSub, ldc, alpha)
+// sgemmPackA copies the mc×kc block of alpha * op(A) starting at row i and
+// column p into dst. The block is stored as consecutive slivers of mrBlock
+// rows, and each sliver is stored column by column so that the kernel reads
+// the mrBlock multipliers for a row of B contiguously.
+func sgemmPackA(aTrans bool, mc, kc int, a []float32, lda, i, p int, alpha float32, dst []float32) {
+	var o int
+	for ir := 0; ir < mc; ir += mrBlock {
+		mr := min(mrBlock, mc-ir)
+		for l := 0; l < kc; l++ {
+			if aTrans {
+				for r, v := range a[(p+l)*lda+i+ir : (p+l)*lda+i+ir+mr] {
+					dst[o+r] = alpha * v
+				}
+			} else {
+				for r := 0; r < mr; r++ {
+					dst[o+r] = alpha * a[(i+ir+r)*lda+p+l]
 				}
 			}
-		}()
+			o += mr
+		}
 	}
+}
 
-	// Send out all of the {i, j} subblocks for computation.
-	for i := 0; i < m; i += blockSize {
-		for j := 0; j < n; j += blockSize {
-			sendChan <- subMul{
-				i: i,
-				j: j,
+// sgemmPackB copies the kc×nc block of op(B) starting at row p and column j
+// into dst, stored by rows.
+func sgemmPackB(bTrans bool, kc, nc int, b []float32, ldb, p, j int, dst []float32) {
+	for l := 0; l < kc; l++ {
+		row := dst[l*nc : l*nc+nc]
+		if bTrans {
+			for jj := range row {
+				row[jj] = b[(j+jj)*ldb+p+l]
+			}
+		} else {
+			copy(row, b[(p+l)*ldb+j:(p+l)*ldb+j+nc])
+		}
+	}
+}
+
+// sgemmKernel computes C += A * B where A is an m×k block packed by sgemmPackA
+// and B is a k×n block packed by sgemmPackB. The rows of C are updated
+// mrBlock at a time so that each row of B is used for mrBlock updates while
+// it is held in cache.
+func sgemmKernel(m, n, k int, a, b, c []float32, ldc int) {
+	for ir := 0; ir < m; ir += mrBlock {
+		mr := min(mrBlock, m-ir)
+		aSliver := a[ir*k : ir*k+mr*k]
+		ci := ir * ldc
+		if mr == 4 {
+			c0 := c[ci : ci+n]
+			c1 := c[ci+ldc : ci+ldc+n]
+			c2 := c[ci+2*ldc : ci+2*ldc+n]
+			c3 := c[ci+3*ldc : ci+3*ldc+n]
+			for l := 0; l < k; l++ {
+				brow := b[l*n : l*n+n]
+				av := aSliver[4*l : 4*l+4]
+				if av[0] != 0 {
+					f32.AxpyUnitary(av[0], brow, c0)
+				}
+				if av[1] != 0 {
+					f32.AxpyUnitary(av[1], brow, c1)
+				}
+				if av[2] != 0 {
+					f32.AxpyUnitary(av[2], brow, c2)
+				}
+				if av[3] != 0 {
+					f32.AxpyUnitary(av[3], brow, c3)
+				}
+			}
+			continue
+		}
+		for l := 0; l < k; l++ {
+			brow := b[l*n : l*n+n]
+			for r, v := range aSliver[l*mr : l*mr+mr] {
+				if v != 0 {
+					f32.AxpyUnitary(v, brow, c[ci+r*ldc:ci+r*ldc+n])
+				}
 			}
 		}
 	}
-	close(sendChan)
-	wg.Wait()
 }
 
 // sgemmSerial is serial matrix multiply
@@ -278,3 +592,29 @@ func checkMatrix32(m, n int, a []float32, lda int) {
 		panic("blas: insufficient matrix slice length")
 	}
 }
+
+// checkBatchMatrix64 is checkMatrix64 for entry i of a batch. The panic
+// string of a failed check names the entry.
+func checkBatchMatrix32(i, m, n int, a []float32, lda int) {
+	if m < 0 {
+		panic(batchEntry("blas: rows < 0", i))
+	}
+	if n < 0 {
+		panic(batchEntry("blas: cols < 0", i))
+	}
+	if lda < n {
+		panic(batchEntry("blas: illegal stride", i))
+	}
+	if len(a) < (m-1)*lda+n {
+		panic(batchEntry("blas: insufficient matrix slice length", i))
+	}
+}
+
+// checkStrided64 checks the last of batch m×n matrices that start stride
+// elements apart in a. With non-negative strides, the others lie within it.
+func checkStrided32(m, n int, a []float32, lda, stride, batch int) {
+	if len(a) < (batch-1)*stride {
+		panic(batchEntry("blas: insufficient matrix slice length", batch-1))
+	}
+	checkBatchMatrix32(batch-1, m, n, a[(batch-1)*stride:], lda)
+}
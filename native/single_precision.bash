@@ -4,10 +4,20 @@
 # Use of this source code is governed by a BSD-style
 # license that can be found in the LICENSE file.
 
-WARNING='//\
-// Float32 implementations are autogenerated and not directly tested.\
+CWARNING='//\
+// Complex64 implementations are autogenerated and not directly tested.\
 '
 
+# stripReproducible deletes the float64-only "if impl.Reproducible {" blocks,
+# from the opening line to the closing brace at the same indentation.
+stripReproducible() {
+	awk '
+		stop != "" { if ($0 == stop) stop = ""; next }
+		/^\t*if impl\.Reproducible {$/ { stop = $0; sub(/if.*/, "}", stop); next }
+		{ print }
+	'
+}
+
 # Level1 routines.
 
 echo Generating level1single.go
@@ -24,9 +34,10 @@ cat level1double.go \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 | gofmt -r 'f64.ScalUnitary -> f32.ScalUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
+| stripReproducible \
+| sed -e "s_^func (\(impl \)\{0,1\}Implementation) D\(.*\)\$_func (Implementation) S\2_" \
       -e 's_^// D_// S_' \
-      -e "s_^\(func (Implementation) \)Id\(.*\)\$_$WARNING\1Is\2_" \
+      -e "s_^\(func (Implementation) \)Id\(.*\)\$_\1Is\2_" \
       -e 's_^// Id_// Is_' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
       -e 's_"math"_math "github.com/gonum/blas/native/internal/math32"_' \
@@ -40,7 +51,8 @@ cat level1double_ddot.go \
 | gofmt -r 'f64.DotInc -> f32.DotInc' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
+| stripReproducible \
+| sed -e "s_^func (\(impl \)\{0,1\}Implementation) D\(.*\)\$_func (Implementation) S\2_" \
       -e 's_^// D_// S_' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> level1single_sdot.go
@@ -53,7 +65,8 @@ cat level1double_ddot.go \
 | gofmt -r 'f64.DotInc -> f32.DdotInc' \
 | gofmt -r 'f64.DotUnitary -> f32.DdotUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1Ds\2_" \
+| stripReproducible \
+| sed -e "s_^func (\(impl \)\{0,1\}Implementation) D\(.*\)\$_func (Implementation) Ds\2_" \
       -e 's_^// D_// Ds_' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> level1single_dsdot.go
@@ -66,10 +79,12 @@ cat level1double_ddot.go \
 | gofmt -r 'f64.DotInc(x, y, f(n), f(incX), f(incY), f(ix), f(iy)) -> alpha + float32(f32.DdotInc(x, y, f(n), f(incX), f(incY), f(ix), f(iy)))' \
 | gofmt -r 'f64.DotUnitary(a, b) -> alpha + float32(f32.DdotUnitary(a, b))' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1Sds\2_" \
+| stripReproducible \
+| sed -e "s_^func (\(impl \)\{0,1\}Implementation) D\(.*\)\$_func (Implementation) Sds\2_" \
       -e 's_^// D\(.*\)$_// Sds\1 plus a constant_' \
       -e 's_\\sum_alpha + \\sum_' \
       -e 's/n int/n int, alpha float32/' \
+      -e 's_return 0$_return alpha_' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> level1single_sdsdot.go
 
@@ -92,8 +107,18 @@ cat level2double.go \
 | gofmt -r 'f64.DotInc -> f32.DotInc' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
+| gofmt -r 'dgemvRows -> sgemvRows' \
+| gofmt -r 'dgemvCols -> sgemvCols' \
+| gofmt -r 'dgerRows -> sgerRows' \
+| gofmt -r 'dsymvParallel -> ssymvParallel' \
+| gofmt -r 'dsymvRows -> ssymvRows' \
+| gofmt -r 'serial.Dgemv -> serial.Sgemv' \
+\
+| stripReproducible \
+| sed -e "s_^\(func (\(impl \)\{0,1\}Implementation) \)D\(.*\)\$_\1S\3_" \
       -e 's_^// D_// S_' \
+      -e 's_^// d_// s_' \
+      -e '/^\s*\/\//s_\bDgemv\b_Sgemv_g' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> level2single.go
 
@@ -107,11 +132,22 @@ cat level3double.go \
 \
 | gofmt -r 'float64 -> float32' \
 \
+| gofmt -r 'dgemmSerial -> sgemmSerial' \
+| gofmt -r 'dscaleBlock -> sscaleBlock' \
+| gofmt -r 'dsyrkSerial -> ssyrkSerial' \
+| gofmt -r 'dsyr2kSerial -> ssyr2kSerial' \
+| gofmt -r 'dtrsmSerial -> strsmSerial' \
+| gofmt -r 'dtrmmSerial -> strmmSerial' \
+| gofmt -r 'dgemmParallel -> sgemmParallel' \
+| gofmt -r 'sliceView64 -> sliceView32' \
+\
 | gofmt -r 'f64.AxpyUnitaryTo -> f32.AxpyUnitaryTo' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
+| sed -e "s_^\(func (\(impl \)\{0,1\}Implementation) \)D\(.*\)\$_\1S\3_" \
       -e 's_^// D_// S_' \
+      -e 's_^// d_// s_' \
+      -e '/^\s*\/\//s_\bD\(gemm\|trsm\|trmm\)\b_S\1_g' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> level3single.go
 
@@ -135,22 +171,150 @@ cat dgemm.go \
 | gofmt -r 'general64 -> general32' \
 | gofmt -r 'sliceView64 -> sliceView32' \
 | gofmt -r 'checkMatrix64 -> checkMatrix32' \
+| gofmt -r 'checkStrided64 -> checkStrided32' \
+| gofmt -r 'checkBatchMatrix64 -> checkBatchMatrix32' \
 \
 | gofmt -r 'dgemmParallel -> sgemmParallel' \
+| gofmt -r 'dscaleBlock -> sscaleBlock' \
 | gofmt -r 'computeNumBlocks64 -> computeNumBlocks32' \
 | gofmt -r 'dgemmSerial -> sgemmSerial' \
 | gofmt -r 'dgemmSerialNotNot -> sgemmSerialNotNot' \
 | gofmt -r 'dgemmSerialTransNot -> sgemmSerialTransNot' \
 | gofmt -r 'dgemmSerialNotTrans -> sgemmSerialNotTrans' \
 | gofmt -r 'dgemmSerialTransTrans -> sgemmSerialTransTrans' \
+| gofmt -r 'dgemmSplitK -> sgemmSplitK' \
+| gofmt -r 'dgemmPacked -> sgemmPacked' \
+| gofmt -r 'dgemmPackA -> sgemmPackA' \
+| gofmt -r 'dgemmPackB -> sgemmPackB' \
+| gofmt -r 'dgemmKernel -> sgemmKernel' \
 \
 | gofmt -r 'f64.AxpyInc -> f32.AxpyInc' \
 | gofmt -r 'f64.AxpyIncTo -> f32.AxpyIncTo' \
+| gofmt -r 'f64.AxpyUnitary -> f32.AxpyUnitary' \
 | gofmt -r 'f64.AxpyUnitaryTo -> f32.AxpyUnitaryTo' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 \
-| sed -e "s_^\(func (Implementation) \)D\(.*\)\$_$WARNING\1S\2_" \
+| sed -e "s_^\(func (impl Implementation) \)D\(.*\)\$_\1S\2_" \
       -e 's_^// D_// S_' \
       -e 's_^// d_// s_' \
+      -e 's_^\(\s*\)// dgemmPacked_\1// sgemmPacked_' \
+      -e 's_by dgemm\(Pack[AB]\|Packed\)_by sgemm\1_' \
+      -e 's_to Dgemm,_to Sgemm,_' \
+      -e 's_with dgemmKernel_with sgemmKernel_' \
       -e 's_"github.com/gonum/internal/asm/f64"_"github.com/gonum/internal/asm/f32"_' \
 >> sgemm.go
+
+
+# Complex64 routines.
+
+echo Generating level1cmplx64.go
+echo -e '// Code generated by "go generate github.com/gonum/blas/native"; DO NOT EDIT.\n' > level1cmplx64.go
+cat level1cmplx128.go \
+| gofmt -r 'blas.Complex128Level1 -> blas.Complex64Level1' \
+\
+| gofmt -r 'float64 -> float32' \
+| gofmt -r 'complex128 -> complex64' \
+\
+| gofmt -r 'dcabs1 -> scabs1' \
+\
+| gofmt -r 'c128.AxpyInc -> c64.AxpyInc' \
+| gofmt -r 'c128.AxpyUnitary -> c64.AxpyUnitary' \
+| gofmt -r 'c128.DotcInc -> c64.DotcInc' \
+| gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
+| gofmt -r 'c128.DotuInc -> c64.DotuInc' \
+| gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+| gofmt -r 'c128.ScalInc -> c64.ScalInc' \
+| gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
+\
+| sed -e "s_^\(func (Implementation) \)Dz\(.*\)\$_$CWARNING\1Sc\2_" \
+      -e 's_^// Dz_// Sc_' \
+      -e "s_^\(func (Implementation) \)Iz\(.*\)\$_$CWARNING\1Ic\2_" \
+      -e 's_^// Iz_// Ic_' \
+      -e "s_^\(func (Implementation) \)Zdscal\(.*\)\$_$CWARNING\1Csscal\2_" \
+      -e 's_^// Zdscal_// Csscal_' \
+      -e "s_^\(func (Implementation) \)Z\(.*\)\$_$CWARNING\1C\2_" \
+      -e 's_^// Z_// C_' \
+      -e 's_^// dcabs1_// scabs1_' \
+      -e 's_"github.com/gonum/internal/asm/c128"_"github.com/gonum/internal/asm/c64"_' \
+      -e 's_"math"_math "github.com/gonum/blas/native/internal/math32"_' \
+>> level1cmplx64.go
+
+echo Generating level2cmplx64.go
+echo -e '// Code generated by "go generate github.com/gonum/blas/native"; DO NOT EDIT.\n' > level2cmplx64.go
+cat level2cmplx128.go \
+| gofmt -r 'blas.Complex128Level2 -> blas.Complex64Level2' \
+\
+| gofmt -r 'float64 -> float32' \
+| gofmt -r 'complex128 -> complex64' \
+\
+| gofmt -r 'Zscal -> Cscal' \
+| gofmt -r 'checkZger -> checkCger' \
+\
+| gofmt -r 'cmplx.Conj -> cmplx64.Conj' \
+\
+| gofmt -r 'c128.AxpyInc -> c64.AxpyInc' \
+| gofmt -r 'c128.AxpyUnitary -> c64.AxpyUnitary' \
+| gofmt -r 'c128.DotcInc -> c64.DotcInc' \
+| gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
+| gofmt -r 'c128.DotuInc -> c64.DotuInc' \
+| gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+| gofmt -r 'c128.ScalInc -> c64.ScalInc' \
+| gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
+\
+| sed -e "s_^\(func (Implementation) \)Z\(.*\)\$_$CWARNING\1C\2_" \
+      -e 's_^// Z_// C_' \
+      -e 's_^// checkZger checks the parameters shared by Zgerc and Zgeru_// checkCger checks the parameters shared by Cgerc and Cgeru_' \
+      -e 's_"github.com/gonum/internal/asm/c128"_"github.com/gonum/internal/asm/c64"_' \
+      -e 's_"math/cmplx"_"github.com/gonum/blas/native/internal/cmplx64"_' \
+>> level2cmplx64.go
+
+echo Generating level3cmplx64.go
+echo -e '// Code generated by "go generate github.com/gonum/blas/native"; DO NOT EDIT.\n' > level3cmplx64.go
+cat level3cmplx128.go \
+| gofmt -r 'blas.Complex128Level3 -> blas.Complex64Level3' \
+\
+| gofmt -r 'float64 -> float32' \
+| gofmt -r 'complex128 -> complex64' \
+\
+| gofmt -r 'cmplx.Conj -> cmplx64.Conj' \
+\
+| gofmt -r 'c128.AxpyInc -> c64.AxpyInc' \
+| gofmt -r 'c128.AxpyUnitary -> c64.AxpyUnitary' \
+| gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
+| gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+| gofmt -r 'c128.ScalUnitary -> c64.ScalUnitary' \
+\
+| sed -e "s_^\(func (Implementation) \)Z\(.*\)\$_$CWARNING\1C\2_" \
+      -e 's_^// Z_// C_' \
+      -e 's_"github.com/gonum/internal/asm/c128"_"github.com/gonum/internal/asm/c64"_' \
+      -e 's_"math/cmplx"_"github.com/gonum/blas/native/internal/cmplx64"_' \
+>> level3cmplx64.go
+
+echo Generating cgemm.go
+echo -e '// Code generated by "go generate github.com/gonum/blas/native"; DO NOT EDIT.\n' > cgemm.go
+cat zgemm.go \
+| gofmt -r 'complex128 -> complex64' \
+| gofmt -r 'sliceViewZ -> sliceViewC' \
+| gofmt -r 'checkZMatrix -> checkCMatrix' \
+\
+| gofmt -r 'zgemmParallel -> cgemmParallel' \
+| gofmt -r 'zgemmSerial -> cgemmSerial' \
+| gofmt -r 'zgemmSerialNotNot -> cgemmSerialNotNot' \
+| gofmt -r 'zgemmSerialTransNot -> cgemmSerialTransNot' \
+| gofmt -r 'zgemmSerialNotTrans -> cgemmSerialNotTrans' \
+| gofmt -r 'zgemmSerialTransTrans -> cgemmSerialTransTrans' \
+\
+| gofmt -r 'cmplx.Conj -> cmplx64.Conj' \
+\
+| gofmt -r 'c128.AxpyInc -> c64.AxpyInc' \
+| gofmt -r 'c128.AxpyUnitary -> c64.AxpyUnitary' \
+| gofmt -r 'c128.DotcUnitary -> c64.DotcUnitary' \
+| gofmt -r 'c128.DotuUnitary -> c64.DotuUnitary' \
+\
+| sed -e "s_^\(func (impl Implementation) \)Z\(.*\)\$_$CWARNING\1C\2_" \
+      -e 's_^// Z_// C_' \
+      -e 's_^// z_// c_' \
+      -e 's_^\(\s*\)// zgemmParallel_\1// cgemmParallel_' \
+      -e 's_"github.com/gonum/internal/asm/c128"_"github.com/gonum/internal/asm/c64"_' \
+      -e 's_"math/cmplx"_"github.com/gonum/blas/native/internal/cmplx64"_' \
+>> cgemm.go
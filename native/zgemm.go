@@ -0,0 +1,245 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math/cmplx"
+	"sync/atomic"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/internal/asm/c128"
+)
+
+// Zgemm performs one of the matrix-matrix operations
+//  C = alpha * op(A) * op(B) + beta * C
+// where op(X) is one of
+//  op(X) = X  or  op(X) = X^T  or  op(X) = X^H,
+// alpha and beta are scalars, and A, B and C are matrices, with op(A) an m×k matrix,
+// op(B) a k×n matrix and C an m×n matrix.
+func (impl Implementation) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	if aTrans {
+		checkZMatrix(k, m, a, lda)
+	} else {
+		checkZMatrix(m, k, a, lda)
+	}
+	bTrans := tB == blas.Trans || tB == blas.ConjTrans
+	if bTrans {
+		checkZMatrix(n, k, b, ldb)
+	} else {
+		checkZMatrix(k, n, b, ldb)
+	}
+	checkZMatrix(m, n, c, ldc)
+
+	// Quick return if possible
+	if m == 0 || n == 0 {
+		return
+	}
+
+	// scale c
+	if beta != 1 {
+		if beta == 0 {
+			for i := 0; i < m; i++ {
+				ctmp := c[i*ldc : i*ldc+n]
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+			}
+		} else {
+			for i := 0; i < m; i++ {
+				ctmp := c[i*ldc : i*ldc+n]
+				for j := range ctmp {
+					ctmp[j] *= beta
+				}
+			}
+		}
+	}
+
+	if alpha == 0 {
+		return
+	}
+
+	impl.zgemmParallel(tA, tB, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+}
+
+func (impl Implementation) zgemmParallel(tA, tB blas.Transpose, m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	// zgemmParallel uses the same {i, j} block partitioning of C as
+	// dgemmParallel. See the comments there for a description of the scheme.
+
+	maxKLen := k
+	bs := impl.blockSize()
+	parBlocks := blocks(m, bs) * blocks(n, bs)
+	if parBlocks < minParBlock {
+		// The matrix multiplication is small in the dimensions where it can be
+		// computed concurrently. Just do it in serial.
+		zgemmSerial(tA, tB, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+		return
+	}
+
+	nWorkers := impl.maxWorkers()
+	if parBlocks < nWorkers {
+		nWorkers = parBlocks
+	}
+
+	// Run workers from the pool. A worker takes {i, j} submatrices of c in
+	// turn, and computes op(A)_ik op(B)_kj storing the result in c_ij. A
+	// worker returns when all of the submatrices have been taken.
+	aTrans := tA != blas.NoTrans
+	bTrans := tB != blas.NoTrans
+	nbj := blocks(n, bs)
+	var next int64
+	workers.parallel(nWorkers, func() {
+		// Make local copies of otherwise global variables to reduce shared memory.
+		alpha := alpha
+		tA := tA
+		tB := tB
+		m := m
+		n := n
+		for {
+			sub := int(atomic.AddInt64(&next, 1) - 1)
+			if sub >= parBlocks {
+				return
+			}
+			i := (sub / nbj) * bs
+			j := (sub % nbj) * bs
+			leni := bs
+			if i+leni > m {
+				leni = m - i
+			}
+			lenj := bs
+			if j+lenj > n {
+				lenj = n - j
+			}
+
+			cSub := sliceViewZ(c, ldc, i, j, leni, lenj)
+
+			// Compute op(A)_ik op(B)_kj for all k
+			for k := 0; k < maxKLen; k += bs {
+				lenk := bs
+				if k+lenk > maxKLen {
+					lenk = maxKLen - k
+				}
+				var aSub, bSub []complex128
+				if aTrans {
+					aSub = sliceViewZ(a, lda, k, i, lenk, leni)
+				} else {
+					aSub = sliceViewZ(a, lda, i, k, leni, lenk)
+				}
+				if bTrans {
+					bSub = sliceViewZ(b, ldb, j, k, lenj, lenk)
+				} else {
+					bSub = sliceViewZ(b, ldb, k, j, lenk, lenj)
+				}
+				zgemmSerial(tA, tB, leni, lenj, lenk, aSub, lda, bSub, ldb, cSub, ldc, alpha)
+			}
+		}
+	})
+}
+
+// zgemmSerial is serial matrix multiply
+func zgemmSerial(tA, tB blas.Transpose, m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	switch {
+	case tA == blas.NoTrans && tB == blas.NoTrans:
+		zgemmSerialNotNot(m, n, k, a, lda, b, ldb, c, ldc, alpha)
+	case tA != blas.NoTrans && tB == blas.NoTrans:
+		zgemmSerialTransNot(tA == blas.ConjTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+	case tA == blas.NoTrans && tB != blas.NoTrans:
+		zgemmSerialNotTrans(tB == blas.ConjTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+	default:
+		zgemmSerialTransTrans(tA == blas.ConjTrans, tB == blas.ConjTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+	}
+}
+
+// zgemmSerial where neither a nor b are transposed
+func zgemmSerialNotNot(m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		for l, v := range a[i*lda : i*lda+k] {
+			tmp := alpha * v
+			if tmp != 0 {
+				c128.AxpyUnitary(tmp, b[l*ldb:l*ldb+n], ctmp)
+			}
+		}
+	}
+}
+
+// zgemmSerial where a is transposed or conjugate transposed and b is not
+func zgemmSerialTransNot(conjA bool, m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	for l := 0; l < k; l++ {
+		btmp := b[l*ldb : l*ldb+n]
+		for i, v := range a[l*lda : l*lda+m] {
+			if conjA {
+				v = cmplx.Conj(v)
+			}
+			tmp := alpha * v
+			if tmp != 0 {
+				c128.AxpyUnitary(tmp, btmp, c[i*ldc:i*ldc+n])
+			}
+		}
+	}
+}
+
+// zgemmSerial where a is not transposed and b is transposed or conjugate
+// transposed
+func zgemmSerialNotTrans(conjB bool, m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	for i := 0; i < m; i++ {
+		atmp := a[i*lda : i*lda+k]
+		ctmp := c[i*ldc : i*ldc+n]
+		for j := 0; j < n; j++ {
+			if conjB {
+				ctmp[j] += alpha * c128.DotcUnitary(b[j*ldb:j*ldb+k], atmp)
+			} else {
+				ctmp[j] += alpha * c128.DotuUnitary(atmp, b[j*ldb:j*ldb+k])
+			}
+		}
+	}
+}
+
+// zgemmSerial where both a and b are transposed or conjugate transposed
+func zgemmSerialTransTrans(conjA, conjB bool, m, n, k int, a []complex128, lda int, b []complex128, ldb int, c []complex128, ldc int, alpha complex128) {
+	for l := 0; l < k; l++ {
+		for i, v := range a[l*lda : l*lda+m] {
+			if conjA {
+				v = cmplx.Conj(v)
+			}
+			tmp := alpha * v
+			if tmp != 0 {
+				ctmp := c[i*ldc : i*ldc+n]
+				if conjB {
+					for j := range ctmp {
+						ctmp[j] += tmp * cmplx.Conj(b[j*ldb+l])
+					}
+				} else {
+					c128.AxpyInc(tmp, b[l:], ctmp, uintptr(n), uintptr(ldb), 1, 0, 0)
+				}
+			}
+		}
+	}
+}
+
+func sliceViewZ(a []complex128, lda, i, j, r, c int) []complex128 {
+	return a[i*lda+j : (i+r-1)*lda+j+c]
+}
+
+func checkZMatrix(m, n int, a []complex128, lda int) {
+	if m < 0 {
+		panic("blas: rows < 0")
+	}
+	if n < 0 {
+		panic("blas: cols < 0")
+	}
+	if lda < n {
+		panic("blas: illegal stride")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("blas: insufficient matrix slice length")
+	}
+}
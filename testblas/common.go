@@ -2,6 +2,8 @@ package testblas
 
 import (
 	"math"
+	"math/cmplx"
+	"math/rand"
 	"testing"
 
 	"github.com/gonum/blas"
@@ -11,6 +13,12 @@ import (
 const throwPanic = true
 
 func dTolEqual(a, b float64) bool {
+	return dTolEqualTol(a, b, 1e-14)
+}
+
+// dTolEqualTol reports whether a and b are equal to within tol, relative to
+// the larger of their magnitudes when that exceeds one.
+func dTolEqualTol(a, b, tol float64) bool {
 	if math.IsNaN(a) && math.IsNaN(b) {
 		return true
 	}
@@ -22,10 +30,7 @@ func dTolEqual(a, b float64) bool {
 		a /= m
 		b /= m
 	}
-	if math.Abs(a-b) < 1e-14 {
-		return true
-	}
-	return false
+	return math.Abs(a-b) < tol
 }
 
 func dSliceTolEqual(a, b []float64) bool {
@@ -235,3 +240,468 @@ func makeIncremented(x []float64, inc int, extra int) []float64 {
 	}
 	return xnew
 }
+
+func zTolEqual(a, b complex128) bool {
+	return dTolEqual(real(a), real(b)) && dTolEqual(imag(a), imag(b))
+}
+
+func zSliceTolEqual(a, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !zTolEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func zSliceEqual(a, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !(a[i] == b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func zSliceCopy(a []complex128) []complex128 {
+	n := make([]complex128, len(a))
+	copy(n, a)
+	return n
+}
+
+// randomZSlice returns a slice of n complex numbers with real and imaginary
+// parts uniformly distributed in [-1, 1).
+func randomZSlice(n int, rnd *rand.Rand) []complex128 {
+	s := make([]complex128, n)
+	for i := range s {
+		s[i] = complex(2*rnd.Float64()-1, 2*rnd.Float64()-1)
+	}
+	return s
+}
+
+// makeZVector returns a strided version of the vector x with increment inc,
+// laid out as BLAS expects it, so that for inc < 0 the elements of x are
+// stored in reverse order. Elements between the vector entries are set to
+// unique values so that writes outside the vector can be detected.
+func makeZVector(x []complex128, inc int) []complex128 {
+	if inc == 0 {
+		panic("zero inc")
+	}
+	n := len(x)
+	if n == 0 {
+		return nil
+	}
+	absinc := inc
+	if absinc < 0 {
+		absinc = -inc
+	}
+	xnew := make([]complex128, (n-1)*absinc+1)
+	for i := range xnew {
+		xnew[i] = complex(float64(100+i), -float64(100+i))
+	}
+	for i, v := range x {
+		if inc > 0 {
+			xnew[i*inc] = v
+		} else {
+			xnew[(n-1-i)*absinc] = v
+		}
+	}
+	return xnew
+}
+
+// makeZGeneral returns an m×n general matrix with leading dimension ld whose
+// elements are taken from the row-major slice a with stride n. Elements
+// outside the m×n part are set to unique values so that accesses outside the
+// matrix can be detected.
+func makeZGeneral(a []complex128, m, n, ld int) []complex128 {
+	if m == 0 {
+		return nil
+	}
+	anew := make([]complex128, m*ld)
+	for i := range anew {
+		anew[i] = complex(float64(100+i), -float64(100+i))
+	}
+	for i := 0; i < m; i++ {
+		copy(anew[i*ld:i*ld+n], a[i*n:i*n+n])
+	}
+	return anew
+}
+
+// zPackBand returns the m×n dense matrix a with stride n in band storage with
+// kL sub-diagonals, kU super-diagonals and leading dimension ld. Unused
+// elements of the band storage are set to unique values.
+func zPackBand(kL, kU, ld, m, n int, a []complex128) []complex128 {
+	if m == 0 {
+		return nil
+	}
+	band := make([]complex128, m*ld)
+	for i := range band {
+		band[i] = complex(float64(100+i), -float64(100+i))
+	}
+	for i := 0; i < m; i++ {
+		for j := max(0, i-kL); j < min(n, i+kU+1); j++ {
+			band[i*ld+kL+j-i] = a[i*n+j]
+		}
+	}
+	return band
+}
+
+// zPackTri returns the ul triangle of the n×n dense matrix a with stride n
+// in packed storage.
+func zPackTri(ul blas.Uplo, n int, a []complex128) []complex128 {
+	ap := make([]complex128, 0, n*(n+1)/2)
+	for i := 0; i < n; i++ {
+		if ul == blas.Upper {
+			ap = append(ap, a[i*n+i:i*n+n]...)
+		} else {
+			ap = append(ap, a[i*n:i*n+i+1]...)
+		}
+	}
+	return ap
+}
+
+// zHermDense returns the n×n Hermitian matrix whose ul triangle is stored in
+// a as a dense matrix with stride n. The imaginary parts of the diagonal of a
+// are ignored.
+func zHermDense(ul blas.Uplo, n int, a []complex128, lda int) []complex128 {
+	h := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var v complex128
+			if ul == blas.Upper {
+				v = a[i*lda+j]
+			} else {
+				v = cmplx.Conj(a[j*lda+i])
+			}
+			if i == j {
+				v = complex(real(v), 0)
+			}
+			h[i*n+j] = v
+			h[j*n+i] = cmplx.Conj(v)
+		}
+	}
+	return h
+}
+
+// zSymDense returns the n×n complex symmetric matrix whose ul triangle is
+// stored in a as a dense matrix with stride n.
+func zSymDense(ul blas.Uplo, n int, a []complex128, lda int) []complex128 {
+	sym := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := a[i*lda+j]
+			if ul == blas.Lower {
+				v = a[j*lda+i]
+			}
+			sym[i*n+j] = v
+			sym[j*n+i] = v
+		}
+	}
+	return sym
+}
+
+// zTriDense returns op(A) as a dense matrix with stride n, where A is the
+// n×n triangular matrix whose ul triangle is stored in a. upper reports
+// whether op(A) is upper triangular.
+func zTriDense(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int) (t []complex128, upper bool) {
+	tri := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (ul == blas.Upper && j >= i) || (ul == blas.Lower && j <= i) {
+				tri[i*n+j] = a[i*lda+j]
+			}
+		}
+		if d == blas.Unit {
+			tri[i*n+i] = 1
+		}
+	}
+	t = make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			t[i*n+j] = zop(tA, tri, n, i, j)
+		}
+	}
+	return t, (ul == blas.Upper) == (tA == blas.NoTrans)
+}
+
+// zop returns the (i, j) element of op(A).
+func zop(tA blas.Transpose, a []complex128, lda, i, j int) complex128 {
+	switch tA {
+	case blas.NoTrans:
+		return a[i*lda+j]
+	case blas.Trans:
+		return a[j*lda+i]
+	default:
+		return cmplx.Conj(a[j*lda+i])
+	}
+}
+
+// zidx returns the index of the i-th element of a vector of length n with
+// increment inc.
+func zidx(i, n, inc int) int {
+	if inc < 0 {
+		return (n - 1 - i) * -inc
+	}
+	return i * inc
+}
+
+// zmv computes
+//  y = alpha * op(A) * x + beta * y
+// for an m×n matrix A using a naive algorithm. y is not read when beta is zero.
+func zmv(tA blas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	for i := 0; i < lenY; i++ {
+		var s complex128
+		for j := 0; j < lenX; j++ {
+			s += zop(tA, a, lda, i, j) * x[zidx(j, lenX, incX)]
+		}
+		iy := zidx(i, lenY, incY)
+		if beta == 0 {
+			y[iy] = alpha * s
+		} else {
+			y[iy] = alpha*s + beta*y[iy]
+		}
+	}
+}
+
+// zmm computes
+//  C = alpha * op(A) * op(B) + beta * C
+// where op(A) is m×k and op(B) is k×n using a naive algorithm. C is not read
+// when beta is zero.
+func zmm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var s complex128
+			for l := 0; l < k; l++ {
+				s += zop(tA, a, lda, i, l) * zop(tB, b, ldb, l, j)
+			}
+			if beta == 0 {
+				c[i*ldc+j] = alpha * s
+			} else {
+				c[i*ldc+j] = alpha*s + beta*c[i*ldc+j]
+			}
+		}
+	}
+}
+
+// ztrsvRef solves t * x = b in place by substitution, where t is an n×n
+// dense triangular matrix with stride n and x holds b on entry.
+func ztrsvRef(upper bool, n int, t []complex128, x []complex128, incX int) {
+	if upper {
+		for i := n - 1; i >= 0; i-- {
+			s := x[zidx(i, n, incX)]
+			for j := i + 1; j < n; j++ {
+				s -= t[i*n+j] * x[zidx(j, n, incX)]
+			}
+			x[zidx(i, n, incX)] = s / t[i*n+i]
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		s := x[zidx(i, n, incX)]
+		for j := 0; j < i; j++ {
+			s -= t[i*n+j] * x[zidx(j, n, incX)]
+		}
+		x[zidx(i, n, incX)] = s / t[i*n+i]
+	}
+}
+
+// randomZTriangular returns a random n×n dense matrix with stride n that is
+// diagonally dominant, so that triangular solves with it, with or without a
+// unit diagonal, are well conditioned.
+func randomZTriangular(n int, rnd *rand.Rand) []complex128 {
+	a := randomZSlice(n*n, rnd)
+	for i := range a {
+		a[i] /= complex(float64(n), 0)
+	}
+	for i := 0; i < n; i++ {
+		a[i*n+i] += 2
+	}
+	return a
+}
+
+// zNaNSlice returns a slice of n complex NaN values.
+func zNaNSlice(n int) []complex128 {
+	s := make([]complex128, n)
+	for i := range s {
+		s[i] = cmplx.NaN()
+	}
+	return s
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Tolerances used by the float32 tests. Level 2 and Level 3 results are
+// longer sums than Level 1 results and so are compared less strictly.
+const (
+	sTol    = 1e-5
+	sAccTol = 1e-4
+)
+
+// sTolEqual reports whether a and b are equal to within tol in the sense of
+// dTolEqualTol.
+func sTolEqual(a, b float32, tol float64) bool {
+	return dTolEqualTol(float64(a), float64(b), tol)
+}
+
+func sSliceTolEqual(a, b []float32, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sTolEqual(a[i], b[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func sSliceEqual(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !(a[i] == b[i]) && !(math.IsNaN(float64(a[i])) && math.IsNaN(float64(b[i]))) {
+			return false
+		}
+	}
+	return true
+}
+
+func sSliceCopy(a []float32) []float32 {
+	n := make([]float32, len(a))
+	copy(n, a)
+	return n
+}
+
+// toFloat32 returns a copy of a converted to float32.
+func toFloat32(a []float64) []float32 {
+	if a == nil {
+		return nil
+	}
+	s := make([]float32, len(a))
+	for i, v := range a {
+		s[i] = float32(v)
+	}
+	return s
+}
+
+// randomSSlice returns a slice of n float32 values uniformly distributed in
+// [-1, 1).
+func randomSSlice(n int, rnd *rand.Rand) []float32 {
+	s := make([]float32, n)
+	for i := range s {
+		s[i] = float32(2*rnd.Float64() - 1)
+	}
+	return s
+}
+
+// sNaNSlice returns a slice of n float32 NaN values.
+func sNaNSlice(n int) []float32 {
+	s := make([]float32, n)
+	for i := range s {
+		s[i] = float32(math.NaN())
+	}
+	return s
+}
+
+// The float32 reference computations below are performed in complex128 by
+// the corresponding complex helpers, which gives them double precision.
+
+func zFromS(a []float32) []complex128 {
+	if a == nil {
+		return nil
+	}
+	z := make([]complex128, len(a))
+	for i, v := range a {
+		z[i] = complex(float64(v), 0)
+	}
+	return z
+}
+
+func sFromZ(a []complex128) []float32 {
+	if a == nil {
+		return nil
+	}
+	s := make([]float32, len(a))
+	for i, v := range a {
+		s[i] = float32(real(v))
+	}
+	return s
+}
+
+func makeSVector(x []float32, inc int) []float32 {
+	return sFromZ(makeZVector(zFromS(x), inc))
+}
+
+func makeSGeneral(a []float32, m, n, ld int) []float32 {
+	return sFromZ(makeZGeneral(zFromS(a), m, n, ld))
+}
+
+func sPackBand(kL, kU, ld, m, n int, a []float32) []float32 {
+	return sFromZ(zPackBand(kL, kU, ld, m, n, zFromS(a)))
+}
+
+func sPackTri(ul blas.Uplo, n int, a []float32) []float32 {
+	return sFromZ(zPackTri(ul, n, zFromS(a)))
+}
+
+// sSymDense returns the n×n symmetric matrix whose ul triangle is stored in
+// a as a dense matrix with stride n.
+func sSymDense(ul blas.Uplo, n int, a []float32, lda int) []float32 {
+	return sFromZ(zHermDense(ul, n, zFromS(a), lda))
+}
+
+func sTriDense(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int) (t []float32, upper bool) {
+	tz, upper := zTriDense(ul, tA, d, n, zFromS(a), lda)
+	return sFromZ(tz), upper
+}
+
+func randomSTriangular(n int, rnd *rand.Rand) []float32 {
+	return sFromZ(randomZTriangular(n, rnd))
+}
+
+// smv is the float32 analogue of zmv.
+func smv(tA blas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	yz := zFromS(y)
+	zmv(tA, m, n, complex(float64(alpha), 0), zFromS(a), lda, zFromS(x), incX, complex(float64(beta), 0), yz, incY)
+	copy(y, sFromZ(yz))
+}
+
+// smm is the float32 analogue of zmm.
+func smm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	cz := zFromS(c)
+	zmm(tA, tB, m, n, k, complex(float64(alpha), 0), zFromS(a), lda, zFromS(b), ldb, complex(float64(beta), 0), cz, ldc)
+	copy(c, sFromZ(cz))
+}
+
+// strsvRef is the float32 analogue of ztrsvRef.
+func strsvRef(upper bool, n int, t []float32, x []float32, incX int) {
+	xz := zFromS(x)
+	ztrsvRef(upper, n, zFromS(t), xz, incX)
+	copy(x, sFromZ(xz))
+}
@@ -71,6 +71,48 @@ func DgbmvTest(t *testing.T, blasser Dgbmver) {
 			y:   []float64{-1, -2, -3, -4, -5, -6},
 			ans: []float64{43, 77, 306, 241, 104, 348},
 		},
+		{
+			tA:    blas.NoTrans,
+			m:     6,
+			n:     2,
+			lda:   3,
+			kL:    1,
+			kU:    1,
+			alpha: 2.0,
+			beta:  3.0,
+			a: [][]float64{
+				{2, -1},
+				{3, 4},
+				{0, 5},
+				{0, 0},
+				{0, 0},
+				{0, 0},
+			},
+			x:   []float64{1, 2},
+			y:   []float64{1, 2, 3, 4, 5, 6},
+			ans: []float64{3, 28, 29, 12, 15, 18},
+		},
+		{
+			tA:    blas.Trans,
+			m:     6,
+			n:     2,
+			lda:   3,
+			kL:    1,
+			kU:    1,
+			alpha: 2.0,
+			beta:  3.0,
+			a: [][]float64{
+				{2, -1},
+				{3, 4},
+				{0, 5},
+				{0, 0},
+				{0, 0},
+				{0, 0},
+			},
+			x:   []float64{1, 2, 3, 4, 5, 6},
+			y:   []float64{1, 2},
+			ans: []float64{19, 50},
+		},
 	} {
 		extra := 3
 		aFlat := flattenBanded(test.a, test.kU, test.kL)
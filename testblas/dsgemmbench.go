@@ -0,0 +1,44 @@
+package testblas
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dsgemmer interface {
+	Dsgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float32, lda int, b []float32, ldb int, beta float64, c []float64, ldc int)
+}
+
+func DsgemmBenchmark(b *testing.B, dsgemm Dsgemmer, m, n, k int, tA, tB blas.Transpose) {
+	a := make([]float32, m*k)
+	for i := range a {
+		a[i] = rand.Float32()
+	}
+	bv := make([]float32, k*n)
+	for i := range bv {
+		bv[i] = rand.Float32()
+	}
+	c := make([]float64, m*n)
+	for i := range c {
+		c[i] = rand.Float64()
+	}
+	var lda, ldb int
+	if tA == blas.Trans {
+		lda = m
+	} else {
+		lda = k
+	}
+	if tB == blas.Trans {
+		ldb = k
+	} else {
+		ldb = n
+	}
+	ldc := n
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dsgemm.Dsgemm(tA, tB, m, n, k, 3.0, a, lda, bv, ldb, 1.0, c, ldc)
+	}
+}
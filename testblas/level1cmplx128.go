@@ -0,0 +1,455 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// The complex Level 1 tests check the implementation against straightforward
+// reference computations on random vectors for a range of vector lengths
+// and increments, including negative increments, and check that elements
+// outside the vectors are not modified.
+
+var (
+	zLevel1Ns     = []int{0, 1, 2, 3, 4, 5, 10}
+	zLevel1Incs   = []int{-7, -3, -1, 1, 2, 5}
+	zLevel1PosInc = []int{1, 2, 5}
+)
+
+type Zdotcer interface {
+	Zdotc(n int, x []complex128, incX int, y []complex128, incY int) complex128
+}
+
+func ZdotcTest(t *testing.T, impl Zdotcer) {
+	// x^H * y for x = [1+2i, 3-1i] and y = [2-1i, 1i].
+	if got := impl.Zdotc(2, []complex128{1 + 2i, 3 - 1i}, 1, []complex128{2 - 1i, 1i}, 1); got != -1-2i {
+		t.Errorf("zdotc: mismatch Simple: expected %v, found %v", -1-2i, got)
+	}
+	zdotTest(t, "zdotc", impl.Zdotc, true)
+}
+
+type Zdotuer interface {
+	Zdotu(n int, x []complex128, incX int, y []complex128, incY int) complex128
+}
+
+func ZdotuTest(t *testing.T, impl Zdotuer) {
+	// x^T * y for x = [1+2i, 3-1i] and y = [2-1i, 1i].
+	if got := impl.Zdotu(2, []complex128{1 + 2i, 3 - 1i}, 1, []complex128{2 - 1i, 1i}, 1); got != 5+6i {
+		t.Errorf("zdotu: mismatch Simple: expected %v, found %v", 5+6i, got)
+	}
+	zdotTest(t, "zdotu", impl.Zdotu, false)
+}
+
+func zdotTest(t *testing.T, name string, zdot func(n int, x []complex128, incX int, y []complex128, incY int) complex128, conj bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1Incs {
+			for _, incY := range zLevel1Incs {
+				xd := randomZSlice(n, rnd)
+				yd := randomZSlice(n, rnd)
+				x := makeZVector(xd, incX)
+				y := makeZVector(yd, incY)
+				xCopy := zSliceCopy(x)
+				yCopy := zSliceCopy(y)
+
+				var want complex128
+				for i, v := range xd {
+					if conj {
+						v = cmplx.Conj(v)
+					}
+					want += v * yd[i]
+				}
+
+				got := zdot(n, x, incX, y, incY)
+				prefix := fmt.Sprintf("%v: n=%v,incX=%v,incY=%v", name, n, incX, incY)
+				if !zTolEqual(got, want) {
+					t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+				}
+				if !zSliceEqual(x, xCopy) {
+					t.Errorf("%v: x modified", prefix)
+				}
+				if !zSliceEqual(y, yCopy) {
+					t.Errorf("%v: y modified", prefix)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	y := make([]complex128, 4)
+	testpanics(func() { zdot(-1, x, 1, y, 1) }, name+": n < 0", t)
+	testpanics(func() { zdot(2, x, 0, y, 1) }, name+": incX == 0", t)
+	testpanics(func() { zdot(2, x, 1, y, 0) }, name+": incY == 0", t)
+	testpanics(func() { zdot(3, x, 2, y, 1) }, name+": short x", t)
+	testpanics(func() { zdot(3, x, 1, y, -2) }, name+": short y", t)
+}
+
+type Dznrm2er interface {
+	Dznrm2(n int, x []complex128, incX int) float64
+}
+
+func Dznrm2Test(t *testing.T, impl Dznrm2er) {
+	for _, test := range []struct {
+		name string
+		x    []complex128
+		want float64
+	}{
+		{"Simple", []complex128{3 + 4i, -12i}, 13},
+		{"Zero", []complex128{0, 0, 0}, 0},
+		{"Large", []complex128{3e300 + 4e300i, 12e300}, 13e300},
+		{"Inf", []complex128{1, complex(math.Inf(-1), 1), 2}, math.Inf(1)},
+		{"NaN", []complex128{1, complex(1, math.NaN()), 2}, math.NaN()},
+	} {
+		got := impl.Dznrm2(len(test.x), test.x, 1)
+		if !dTolEqual(got, test.want) {
+			t.Errorf("dznrm2: mismatch %v: expected %v, found %v", test.name, test.want, got)
+		}
+	}
+	if got := impl.Dznrm2(2, []complex128{3e-300 - 4e-300i, -12e-300i}, 1); !dTolEqual(got/1e-300, 13) {
+		t.Errorf("dznrm2: mismatch Small: expected %v, found %v", 13e-300, got)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1PosInc {
+			xd := randomZSlice(n, rnd)
+			x := makeZVector(xd, incX)
+			xCopy := zSliceCopy(x)
+
+			var want float64
+			for _, v := range xd {
+				want += real(v)*real(v) + imag(v)*imag(v)
+			}
+			want = math.Sqrt(want)
+
+			got := impl.Dznrm2(n, x, incX)
+			prefix := fmt.Sprintf("dznrm2: n=%v,incX=%v", n, incX)
+			if !dTolEqual(got, want) {
+				t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+			}
+			if !zSliceEqual(x, xCopy) {
+				t.Errorf("%v: x modified", prefix)
+			}
+			if n > 0 {
+				if got := impl.Dznrm2(n, x, -incX); got != 0 {
+					t.Errorf("%v: unexpected result for negative increment: want 0, got %v", prefix, got)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	testpanics(func() { impl.Dznrm2(-1, x, 1) }, "dznrm2: n < 0", t)
+	testpanics(func() { impl.Dznrm2(2, x, 0) }, "dznrm2: incX == 0", t)
+	testpanics(func() { impl.Dznrm2(3, x, 2) }, "dznrm2: short x", t)
+}
+
+type Dzasumer interface {
+	Dzasum(n int, x []complex128, incX int) float64
+}
+
+func DzasumTest(t *testing.T, impl Dzasumer) {
+	if got := impl.Dzasum(3, []complex128{1 - 2i, -3, 4i}, 1); got != 10 {
+		t.Errorf("dzasum: mismatch Simple: expected %v, found %v", 10, got)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1PosInc {
+			xd := randomZSlice(n, rnd)
+			x := makeZVector(xd, incX)
+			xCopy := zSliceCopy(x)
+
+			var want float64
+			for _, v := range xd {
+				want += math.Abs(real(v)) + math.Abs(imag(v))
+			}
+
+			got := impl.Dzasum(n, x, incX)
+			prefix := fmt.Sprintf("dzasum: n=%v,incX=%v", n, incX)
+			if !dTolEqual(got, want) {
+				t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+			}
+			if !zSliceEqual(x, xCopy) {
+				t.Errorf("%v: x modified", prefix)
+			}
+			if n > 0 {
+				if got := impl.Dzasum(n, x, -incX); got != 0 {
+					t.Errorf("%v: unexpected result for negative increment: want 0, got %v", prefix, got)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	testpanics(func() { impl.Dzasum(-1, x, 1) }, "dzasum: n < 0", t)
+	testpanics(func() { impl.Dzasum(2, x, 0) }, "dzasum: incX == 0", t)
+	testpanics(func() { impl.Dzasum(3, x, 2) }, "dzasum: short x", t)
+}
+
+type Izamaxer interface {
+	Izamax(n int, x []complex128, incX int) int
+}
+
+func IzamaxTest(t *testing.T, impl Izamaxer) {
+	for _, test := range []struct {
+		name string
+		x    []complex128
+		want int
+	}{
+		{"Empty", nil, -1},
+		{"Single", []complex128{-1i}, 0},
+		// Izamax compares |Re(x[i])| + |Im(x[i])| rather than |x[i]|.
+		{"OneNorm", []complex128{3 + 3i, 5, 1 - 4.5i}, 0},
+		{"FirstOfTies", []complex128{1, 2 - 1i, -3, 1 + 2i}, 1},
+	} {
+		got := impl.Izamax(len(test.x), test.x, 1)
+		if got != test.want {
+			t.Errorf("izamax: mismatch %v: expected %v, found %v", test.name, test.want, got)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1PosInc {
+			xd := randomZSlice(n, rnd)
+			x := makeZVector(xd, incX)
+			xCopy := zSliceCopy(x)
+
+			want := -1
+			var max float64
+			for i, v := range xd {
+				abs := math.Abs(real(v)) + math.Abs(imag(v))
+				if want == -1 || abs > max {
+					want = i
+					max = abs
+				}
+			}
+
+			got := impl.Izamax(n, x, incX)
+			prefix := fmt.Sprintf("izamax: n=%v,incX=%v", n, incX)
+			if got != want {
+				t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+			}
+			if !zSliceEqual(x, xCopy) {
+				t.Errorf("%v: x modified", prefix)
+			}
+			if n > 0 {
+				if got := impl.Izamax(n, x, -incX); got != -1 {
+					t.Errorf("%v: unexpected result for negative increment: want -1, got %v", prefix, got)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	testpanics(func() { impl.Izamax(-1, x, 1) }, "izamax: n < 0", t)
+	testpanics(func() { impl.Izamax(2, x, 0) }, "izamax: incX == 0", t)
+	testpanics(func() { impl.Izamax(3, x, 2) }, "izamax: short x", t)
+}
+
+type Zswapper interface {
+	Zswap(n int, x []complex128, incX int, y []complex128, incY int)
+}
+
+func ZswapTest(t *testing.T, impl Zswapper) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1Incs {
+			for _, incY := range zLevel1Incs {
+				xd := randomZSlice(n, rnd)
+				yd := randomZSlice(n, rnd)
+				x := makeZVector(xd, incX)
+				y := makeZVector(yd, incY)
+
+				impl.Zswap(n, x, incX, y, incY)
+
+				prefix := fmt.Sprintf("zswap: n=%v,incX=%v,incY=%v", n, incX, incY)
+				if !zSliceEqual(x, makeZVector(yd, incX)) {
+					t.Errorf("%v: unexpected x", prefix)
+				}
+				if !zSliceEqual(y, makeZVector(xd, incY)) {
+					t.Errorf("%v: unexpected y", prefix)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	y := make([]complex128, 4)
+	testpanics(func() { impl.Zswap(-1, x, 1, y, 1) }, "zswap: n < 0", t)
+	testpanics(func() { impl.Zswap(2, x, 0, y, 1) }, "zswap: incX == 0", t)
+	testpanics(func() { impl.Zswap(2, x, 1, y, 0) }, "zswap: incY == 0", t)
+	testpanics(func() { impl.Zswap(3, x, 2, y, 1) }, "zswap: short x", t)
+	testpanics(func() { impl.Zswap(3, x, 1, y, -2) }, "zswap: short y", t)
+}
+
+type Zcopier interface {
+	Zcopy(n int, x []complex128, incX int, y []complex128, incY int)
+}
+
+func ZcopyTest(t *testing.T, impl Zcopier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1Incs {
+			for _, incY := range zLevel1Incs {
+				xd := randomZSlice(n, rnd)
+				yd := randomZSlice(n, rnd)
+				x := makeZVector(xd, incX)
+				y := makeZVector(yd, incY)
+				xCopy := zSliceCopy(x)
+
+				impl.Zcopy(n, x, incX, y, incY)
+
+				prefix := fmt.Sprintf("zcopy: n=%v,incX=%v,incY=%v", n, incX, incY)
+				if !zSliceEqual(x, xCopy) {
+					t.Errorf("%v: x modified", prefix)
+				}
+				if !zSliceEqual(y, makeZVector(xd, incY)) {
+					t.Errorf("%v: unexpected y", prefix)
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	y := make([]complex128, 4)
+	testpanics(func() { impl.Zcopy(-1, x, 1, y, 1) }, "zcopy: n < 0", t)
+	testpanics(func() { impl.Zcopy(2, x, 0, y, 1) }, "zcopy: incX == 0", t)
+	testpanics(func() { impl.Zcopy(2, x, 1, y, 0) }, "zcopy: incY == 0", t)
+	testpanics(func() { impl.Zcopy(3, x, 2, y, 1) }, "zcopy: short x", t)
+	testpanics(func() { impl.Zcopy(3, x, 1, y, -2) }, "zcopy: short y", t)
+}
+
+type Zaxpyer interface {
+	Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int)
+}
+
+func ZaxpyTest(t *testing.T, impl Zaxpyer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1Incs {
+			for _, incY := range zLevel1Incs {
+				for _, alpha := range []complex128{0, 1, -2 + 3i} {
+					xd := randomZSlice(n, rnd)
+					yd := randomZSlice(n, rnd)
+					x := makeZVector(xd, incX)
+					y := makeZVector(yd, incY)
+					xCopy := zSliceCopy(x)
+
+					want := make([]complex128, n)
+					for i, v := range xd {
+						want[i] = alpha*v + yd[i]
+					}
+
+					impl.Zaxpy(n, alpha, x, incX, y, incY)
+
+					prefix := fmt.Sprintf("zaxpy: n=%v,incX=%v,incY=%v,alpha=%v", n, incX, incY, alpha)
+					if !zSliceEqual(x, xCopy) {
+						t.Errorf("%v: x modified", prefix)
+					}
+					if !zSliceTolEqual(y, makeZVector(want, incY)) {
+						t.Errorf("%v: unexpected y", prefix)
+					}
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	y := make([]complex128, 4)
+	testpanics(func() { impl.Zaxpy(-1, 1, x, 1, y, 1) }, "zaxpy: n < 0", t)
+	testpanics(func() { impl.Zaxpy(2, 1, x, 0, y, 1) }, "zaxpy: incX == 0", t)
+	testpanics(func() { impl.Zaxpy(2, 1, x, 1, y, 0) }, "zaxpy: incY == 0", t)
+	testpanics(func() { impl.Zaxpy(3, 1, x, 2, y, 1) }, "zaxpy: short x", t)
+	testpanics(func() { impl.Zaxpy(3, 1, x, 1, y, -2) }, "zaxpy: short y", t)
+}
+
+type Zscaler interface {
+	Zscal(n int, alpha complex128, x []complex128, incX int)
+}
+
+func ZscalTest(t *testing.T, impl Zscaler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1PosInc {
+			for _, alpha := range []complex128{0, 1, 2 - 1i} {
+				xd := randomZSlice(n, rnd)
+				x := makeZVector(xd, incX)
+
+				want := make([]complex128, n)
+				for i, v := range xd {
+					want[i] = alpha * v
+				}
+
+				impl.Zscal(n, alpha, x, incX)
+
+				prefix := fmt.Sprintf("zscal: n=%v,incX=%v,alpha=%v", n, incX, alpha)
+				if !zSliceTolEqual(x, makeZVector(want, incX)) {
+					t.Errorf("%v: unexpected x", prefix)
+				}
+
+				if n > 0 {
+					x = makeZVector(xd, incX)
+					impl.Zscal(n, alpha, x, -incX)
+					if !zSliceEqual(x, makeZVector(xd, incX)) {
+						t.Errorf("%v: x modified for negative increment", prefix)
+					}
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	testpanics(func() { impl.Zscal(-1, 2, x, 1) }, "zscal: n < 0", t)
+	testpanics(func() { impl.Zscal(2, 2, x, 0) }, "zscal: incX == 0", t)
+	testpanics(func() { impl.Zscal(3, 2, x, 2) }, "zscal: short x", t)
+}
+
+type Zdscaler interface {
+	Zdscal(n int, alpha float64, x []complex128, incX int)
+}
+
+func ZdscalTest(t *testing.T, impl Zdscaler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range zLevel1Ns {
+		for _, incX := range zLevel1PosInc {
+			for _, alpha := range []float64{0, 1, -2.5} {
+				xd := randomZSlice(n, rnd)
+				x := makeZVector(xd, incX)
+
+				want := make([]complex128, n)
+				for i, v := range xd {
+					want[i] = complex(alpha*real(v), alpha*imag(v))
+				}
+
+				impl.Zdscal(n, alpha, x, incX)
+
+				prefix := fmt.Sprintf("zdscal: n=%v,incX=%v,alpha=%v", n, incX, alpha)
+				if !zSliceTolEqual(x, makeZVector(want, incX)) {
+					t.Errorf("%v: unexpected x", prefix)
+				}
+
+				if n > 0 {
+					x = makeZVector(xd, incX)
+					impl.Zdscal(n, alpha, x, -incX)
+					if !zSliceEqual(x, makeZVector(xd, incX)) {
+						t.Errorf("%v: x modified for negative increment", prefix)
+					}
+				}
+			}
+		}
+	}
+
+	x := make([]complex128, 4)
+	testpanics(func() { impl.Zdscal(-1, 2, x, 1) }, "zdscal: n < 0", t)
+	testpanics(func() { impl.Zdscal(2, 2, x, 0) }, "zdscal: incX == 0", t)
+	testpanics(func() { impl.Zdscal(3, 2, x, 2) }, "zdscal: short x", t)
+}
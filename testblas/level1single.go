@@ -0,0 +1,364 @@
+package testblas
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+)
+
+// The float32 Level 1 tests use the float64 test cases converted to float32.
+
+type Sdotter interface {
+	Sdot(n int, x []float32, incX int, y []float32, incY int) float32
+}
+
+func SdotTest(t *testing.T, d Sdotter) {
+	sdot := d.Sdot
+	for _, c := range DoubleTwoVectorCases {
+		x, y := toFloat32(c.X), toFloat32(c.Y)
+		if c.Panic {
+			f := func() { sdot(c.N, x, c.Incx, y, c.Incy) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		dot := sdot(c.N, x, c.Incx, y, c.Incy)
+		if !sTolEqual(dot, float32(c.DdotAns), sTol) {
+			t.Errorf("sdot: mismatch %v: expected %v, found %v", c.Name, c.DdotAns, dot)
+		}
+	}
+
+	// check it works for 16-byte unaligned slices
+	x := []float32{1, 1, 1, 1, 1}
+	if n := sdot(4, x[:4], 1, x[1:], 1); n != 4 {
+		t.Errorf("sdot: mismatch Unaligned: expected %v, found %v", 4, n)
+	}
+	if n := sdot(2, x[:4], 2, x[1:], 2); n != 2 {
+		t.Errorf("sdot: mismatch Unaligned: expected %v, found %v", 2, n)
+	}
+	if n := sdot(2, x[:4], 3, x[1:], 3); n != 2 {
+		t.Errorf("sdot: mismatch Unaligned: expected %v, found %v", 2, n)
+	}
+}
+
+type Dsdotter interface {
+	Dsdot(n int, x []float32, incX int, y []float32, incY int) float64
+}
+
+func DsdotTest(t *testing.T, d Dsdotter) {
+	dsdot := d.Dsdot
+	for _, c := range DoubleTwoVectorCases {
+		x, y := toFloat32(c.X), toFloat32(c.Y)
+		if c.Panic {
+			f := func() { dsdot(c.N, x, c.Incx, y, c.Incy) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		dot := dsdot(c.N, x, c.Incx, y, c.Incy)
+		if !dTolEqual(dot, c.DdotAns) {
+			t.Errorf("dsdot: mismatch %v: expected %v, found %v", c.Name, c.DdotAns, dot)
+		}
+	}
+
+	// The accumulation must be done in float64.
+	x := []float32{1 << 24, 1, 1, 1}
+	y := []float32{1, 1, 1, 1}
+	if dot := dsdot(4, x, 1, y, 1); dot != 1<<24+3 {
+		t.Errorf("dsdot: mismatch Accumulation: expected %v, found %v", 1<<24+3, dot)
+	}
+}
+
+type Sdsdotter interface {
+	Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32
+}
+
+func SdsdotTest(t *testing.T, d Sdsdotter) {
+	sdsdot := d.Sdsdot
+	for _, c := range DoubleTwoVectorCases {
+		x, y := toFloat32(c.X), toFloat32(c.Y)
+		for _, alpha := range []float32{0, 1, -2.5} {
+			if c.Panic {
+				f := func() { sdsdot(c.N, alpha, x, c.Incx, y, c.Incy) }
+				testpanics(f, c.Name, t)
+				continue
+			}
+			dot := sdsdot(c.N, alpha, x, c.Incx, y, c.Incy)
+			if !sTolEqual(dot, float32(float64(alpha)+c.DdotAns), sTol) {
+				t.Errorf("sdsdot: mismatch %v, alpha=%v: expected %v, found %v", c.Name, alpha, float64(alpha)+c.DdotAns, dot)
+			}
+		}
+	}
+}
+
+type Snrm2er interface {
+	Snrm2(n int, x []float32, incX int) float32
+}
+
+func Snrm2Test(t *testing.T, blasser Snrm2er) {
+	snrm2 := blasser.Snrm2
+	for _, c := range DoubleOneVectorCases {
+		x := toFloat32(c.X)
+		if c.Panic {
+			f := func() { snrm2(c.N, x, c.Incx) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		v := snrm2(c.N, x, c.Incx)
+		if !sTolEqual(v, float32(c.Dnrm2), sTol) {
+			t.Errorf("snrm2: mismatch %v: expected %v, found %v", c.Name, c.Dnrm2, v)
+		}
+	}
+
+	// Snrm2 must not overflow or underflow for values whose squares are
+	// not representable in float32.
+	for _, scale := range []float32{1e-30, 1e30} {
+		x := []float32{3 * scale, 4 * scale}
+		if v := snrm2(2, x, 1); !sTolEqual(v/scale, 5, sTol) {
+			t.Errorf("snrm2: mismatch Scale %v: expected %v, found %v", scale, 5*scale, v)
+		}
+	}
+}
+
+type Sasumer interface {
+	Sasum(n int, x []float32, incX int) float32
+}
+
+func SasumTest(t *testing.T, blasser Sasumer) {
+	sasum := blasser.Sasum
+	for _, c := range DoubleOneVectorCases {
+		x := toFloat32(c.X)
+		if c.Panic {
+			f := func() { sasum(c.N, x, c.Incx) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		v := sasum(c.N, x, c.Incx)
+		if !sTolEqual(v, float32(c.Dasum), sTol) {
+			t.Errorf("sasum: mismatch %v: expected %v, found %v", c.Name, c.Dasum, v)
+		}
+	}
+}
+
+type Isamaxer interface {
+	Isamax(n int, x []float32, incX int) int
+}
+
+func IsamaxTest(t *testing.T, blasser Isamaxer) {
+	isamax := blasser.Isamax
+	for _, c := range DoubleOneVectorCases {
+		x := toFloat32(c.X)
+		if c.Panic {
+			f := func() { isamax(c.N, x, c.Incx) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		v := isamax(c.N, x, c.Incx)
+		if v != c.Idamax {
+			s := fmt.Sprintf("isamax: mismatch %v: expected %v, found %v", c.Name, c.Idamax, v)
+			if floats.HasNaN(c.X) {
+				log.Println(s)
+			} else {
+				t.Errorf(s)
+			}
+		}
+	}
+}
+
+type Sswapper interface {
+	Sswap(n int, x []float32, incX int, y []float32, incY int)
+}
+
+func SswapTest(t *testing.T, d Sswapper) {
+	sswap := d.Sswap
+	for _, c := range DoubleTwoVectorCases {
+		x, y := toFloat32(c.X), toFloat32(c.Y)
+		if c.Panic {
+			f := func() { sswap(c.N, x, c.Incx, y, c.Incy) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		sswap(c.N, x, c.Incx, y, c.Incy)
+		if !sSliceEqual(x, toFloat32(c.DswapAns.X)) {
+			t.Errorf("sswap: x mismatch %v: expected %v, found %v", c.Name, c.DswapAns.X, x)
+		}
+		if !sSliceEqual(y, toFloat32(c.DswapAns.Y)) {
+			t.Errorf("sswap: y mismatch %v: expected %v, found %v", c.Name, c.DswapAns.Y, y)
+		}
+	}
+}
+
+type Scopier interface {
+	Scopy(n int, x []float32, incX int, y []float32, incY int)
+}
+
+func ScopyTest(t *testing.T, d Scopier) {
+	scopy := d.Scopy
+	for _, c := range DoubleTwoVectorCases {
+		x, y := toFloat32(c.X), toFloat32(c.Y)
+		if c.Panic {
+			f := func() { scopy(c.N, x, c.Incx, y, c.Incy) }
+			testpanics(f, c.Name, t)
+			continue
+		}
+		scopy(c.N, x, c.Incx, y, c.Incy)
+		if !sSliceEqual(x, toFloat32(c.DcopyAns.X)) {
+			t.Errorf("scopy: x mismatch %v: expected %v, found %v", c.Name, c.DcopyAns.X, x)
+		}
+		if !sSliceEqual(y, toFloat32(c.DcopyAns.Y)) {
+			t.Errorf("scopy: y mismatch %v: expected %v, found %v", c.Name, c.DcopyAns.Y, y)
+		}
+	}
+}
+
+type Saxpyer interface {
+	Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int)
+}
+
+func SaxpyTest(t *testing.T, d Saxpyer) {
+	saxpy := d.Saxpy
+	for _, c := range DoubleTwoVectorCases {
+		for _, kind := range c.DaxpyCases {
+			x, y := toFloat32(c.X), toFloat32(c.Y)
+			alpha := float32(kind.Alpha)
+			if c.Panic {
+				f := func() { saxpy(c.N, alpha, x, c.Incx, y, c.Incy) }
+				testpanics(f, c.Name, t)
+				continue
+			}
+			saxpy(c.N, alpha, x, c.Incx, y, c.Incy)
+			if !sSliceTolEqual(y, toFloat32(kind.Ans), sTol) {
+				t.Errorf("saxpy: mismatch %v: expected %v, found %v", c.Name, kind.Ans, y)
+			}
+		}
+	}
+}
+
+type Srotger interface {
+	Srotg(a, b float32) (c, s, r, z float32)
+}
+
+func SrotgTest(t *testing.T, d Srotger) {
+	srotg := d.Srotg
+	for _, test := range DrotgTests {
+		c, s, r, z := srotg(float32(test.A), float32(test.B))
+		if !sTolEqual(c, float32(test.C), sTol) {
+			t.Errorf("srotg: c mismatch %v: expected %v, found %v", test.Name, test.C, c)
+		}
+		if !sTolEqual(s, float32(test.S), sTol) {
+			t.Errorf("srotg: s mismatch %v: expected %v, found %v", test.Name, test.S, s)
+		}
+		if !sTolEqual(r, float32(test.R), sTol) {
+			t.Errorf("srotg: r mismatch %v: expected %v, found %v", test.Name, test.R, r)
+		}
+		if !sTolEqual(z, float32(test.Z), sTol) {
+			t.Errorf("srotg: z mismatch %v: expected %v, found %v", test.Name, test.Z, z)
+		}
+	}
+}
+
+type Srotmger interface {
+	Srotmg(d1, d2, x1, y1 float32) (p blas.SrotmParams, rd1, rd2, rx1 float32)
+}
+
+func SrotmgTest(t *testing.T, d Srotmger) {
+	for _, test := range DrotmgTests {
+		p, rd1, rd2, rx1 := d.Srotmg(float32(test.D1), float32(test.D2), float32(test.X1), float32(test.Y1))
+
+		if p.Flag != test.P.Flag {
+			t.Errorf("srotmg flag mismatch %v: expected %v, found %v", test.Name, test.P.Flag, p.Flag)
+		}
+		for i, val := range p.H {
+			if !sTolEqual(float32(test.P.H[i]), val, sTol) {
+				t.Errorf("srotmg H mismatch %v: expected %v, found %v", test.Name, test.P.H, p.H)
+				break
+			}
+		}
+		if !sTolEqual(rd1, float32(test.Rd1), sTol) {
+			t.Errorf("srotmg rd1 mismatch %v: expected %v, found %v", test.Name, test.Rd1, rd1)
+		}
+		if !sTolEqual(rd2, float32(test.Rd2), sTol) {
+			t.Errorf("srotmg rd2 mismatch %v: expected %v, found %v", test.Name, test.Rd2, rd2)
+		}
+		if !sTolEqual(rx1, float32(test.Rx1), sTol) {
+			t.Errorf("srotmg rx1 mismatch %v: expected %v, found %v", test.Name, test.Rx1, rx1)
+		}
+	}
+}
+
+type Sroter interface {
+	Srot(n int, x []float32, incX int, y []float32, incY int, c, s float32)
+}
+
+func SrotTest(t *testing.T, d Sroter) {
+	srot := d.Srot
+	for _, c := range DoubleTwoVectorCases {
+		for _, kind := range c.DrotCases {
+			x, y := toFloat32(c.X), toFloat32(c.Y)
+			if c.Panic {
+				f := func() { srot(c.N, x, c.Incx, y, c.Incy, float32(kind.C), float32(kind.S)) }
+				testpanics(f, c.Name, t)
+				continue
+			}
+			srot(c.N, x, c.Incx, y, c.Incy, float32(kind.C), float32(kind.S))
+			if !sSliceTolEqual(x, toFloat32(kind.XAns), sTol) {
+				t.Errorf("srot: x mismatch %v: expected %v, found %v", c.Name, kind.XAns, x)
+			}
+			if !sSliceTolEqual(y, toFloat32(kind.YAns), sTol) {
+				t.Errorf("srot: y mismatch %v: expected %v, found %v", c.Name, kind.YAns, y)
+			}
+		}
+	}
+}
+
+type Srotmer interface {
+	Srotm(n int, x []float32, incX int, y []float32, incY int, p blas.SrotmParams)
+}
+
+func SrotmTest(t *testing.T, d Srotmer) {
+	srotm := d.Srotm
+	for _, c := range DoubleTwoVectorCases {
+		for _, kind := range c.DrotmCases {
+			x, y := toFloat32(c.X), toFloat32(c.Y)
+			p := blas.SrotmParams{Flag: kind.P.Flag}
+			for i, v := range kind.P.H {
+				p.H[i] = float32(v)
+			}
+			if c.Panic {
+				f := func() { srotm(c.N, x, c.Incx, y, c.Incy, p) }
+				testpanics(f, c.Name+", "+kind.Name, t)
+				continue
+			}
+			srotm(c.N, x, c.Incx, y, c.Incy, p)
+			if !sSliceTolEqual(x, toFloat32(kind.XAns), sTol) {
+				t.Errorf("srotm: mismatch %v: expected %v, found %v", c.Name, kind.XAns, x)
+			}
+			if !sSliceTolEqual(y, toFloat32(kind.YAns), sTol) {
+				t.Errorf("srotm: mismatch %v: expected %v, found %v", c.Name, kind.YAns, y)
+			}
+		}
+	}
+}
+
+type Sscaler interface {
+	Sscal(n int, alpha float32, x []float32, incX int)
+}
+
+func SscalTest(t *testing.T, blasser Sscaler) {
+	sscal := blasser.Sscal
+	for _, c := range DoubleOneVectorCases {
+		for _, kind := range c.DscalCases {
+			x := toFloat32(c.X)
+			if c.Panic {
+				f := func() { sscal(c.N, float32(kind.Alpha), x, c.Incx) }
+				testpanics(f, c.Name, t)
+				continue
+			}
+			sscal(c.N, float32(kind.Alpha), x, c.Incx)
+			if !sSliceTolEqual(x, toFloat32(kind.Ans), sTol) {
+				t.Errorf("sscal: mismatch %v, %v: expected %v, found %v", c.Name, kind.Name, kind.Ans, x)
+			}
+		}
+	}
+}
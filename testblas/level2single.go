@@ -0,0 +1,539 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// The float32 Level 2 tests compare against reference results computed in
+// double precision from the same float32 data.
+
+type Sgemver interface {
+	Sgemv(tA blas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int)
+}
+
+func SgemvTest(t *testing.T, impl Sgemver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, test := range []struct {
+			m, n int
+		}{
+			{0, 0}, {0, 3}, {3, 0}, {1, 1}, {2, 3}, {3, 2}, {4, 4}, {7, 5}, {10, 13}, {31, 17},
+		} {
+			m, n := test.m, test.n
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				a := makeSGeneral(randomSSlice(m*n, rnd), m, n, lda)
+				for _, incX := range []int{-4, 1, 2} {
+					x := makeSVector(randomSSlice(lenX, rnd), incX)
+					for _, incY := range []int{-2, 1, 3} {
+						for _, alpha := range []float32{0, 1, -1.3} {
+							for _, beta := range []float32{0, 1, 0.7} {
+								yd := randomSSlice(lenY, rnd)
+								if beta == 0 {
+									yd = sNaNSlice(lenY)
+								}
+								y := makeSVector(yd, incY)
+								want := sSliceCopy(y)
+								smv(tA, m, n, alpha, a, lda, x, incX, beta, want, incY)
+
+								aCopy := sSliceCopy(a)
+								xCopy := sSliceCopy(x)
+								impl.Sgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("tA=%v,m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v", tA, m, n, lda, incX, incY, alpha, beta)
+								if !sSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !sSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !sSliceTolEqual(y, want, sAccTol) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Sgbmver interface {
+	Sgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int)
+}
+
+func SgbmvTest(t *testing.T, impl Sgbmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, test := range []struct {
+			m, n, kL, kU int
+		}{
+			{0, 0, 0, 0}, {0, 3, 1, 1}, {3, 0, 0, 2},
+			{1, 1, 0, 0}, {1, 1, 1, 2},
+			{4, 4, 0, 0}, {4, 4, 1, 2}, {4, 4, 3, 3},
+			{5, 3, 2, 0}, {3, 5, 0, 2}, {7, 6, 1, 3}, {6, 9, 4, 1},
+			{5, 1, 1, 0}, {8, 3, 1, 2},
+		} {
+			m, n, kL, kU := test.m, test.n, test.kL, test.kU
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			aDense := randomSSlice(m*n, rnd)
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					if j < i-kL || j > i+kU {
+						aDense[i*n+j] = 0
+					}
+				}
+			}
+			for _, extra := range []int{0, 3} {
+				lda := kL + kU + 1 + extra
+				a := sPackBand(kL, kU, lda, m, n, aDense)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeSVector(randomSSlice(lenX, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						for _, alpha := range []float32{0, 1, -1.3} {
+							for _, beta := range []float32{0, 1, 0.7} {
+								yd := randomSSlice(lenY, rnd)
+								if beta == 0 {
+									yd = sNaNSlice(lenY)
+								}
+								y := makeSVector(yd, incY)
+								want := sSliceCopy(y)
+								smv(tA, m, n, alpha, aDense, max(1, n), x, incX, beta, want, incY)
+
+								aCopy := sSliceCopy(a)
+								xCopy := sSliceCopy(x)
+								impl.Sgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("tA=%v,m=%v,n=%v,kL=%v,kU=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v",
+									tA, m, n, kL, kU, lda, incX, incY, alpha, beta)
+								if !sSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !sSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !sSliceTolEqual(y, want, sAccTol) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Ssymver interface {
+	Ssymv(ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int)
+}
+
+type Ssbmver interface {
+	Ssbmv(ul blas.Uplo, n, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int)
+}
+
+type Sspmver interface {
+	Sspmv(ul blas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int)
+}
+
+func SsymvTest(t *testing.T, impl Ssymver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				// The opposite triangle holds random values that must not
+				// be referenced.
+				a := makeSGeneral(randomSSlice(n*n, rnd), n, n, lda)
+				sym := sSymDense(ul, n, a, lda)
+				testSymMatVec(t, rnd, fmt.Sprintf("ul=%v", ul), n, sym, a, func(alpha float32, x []float32, incX int, beta float32, y []float32, incY int) {
+					impl.Ssymv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+				})
+			}
+		}
+	}
+}
+
+func SsbmvTest(t *testing.T, impl Ssbmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, k int
+		}{
+			{0, 0}, {0, 2}, {1, 0}, {1, 3}, {3, 1}, {4, 0}, {4, 3}, {5, 2}, {7, 3}, {10, 8},
+		} {
+			n, k := test.n, test.k
+			aDense := randomSSlice(n*n, rnd)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if j < i-k || j > i+k {
+						aDense[i*n+j] = 0
+					}
+				}
+			}
+			sym := sSymDense(ul, n, aDense, max(1, n))
+			kL, kU := 0, k
+			if ul == blas.Lower {
+				kL, kU = k, 0
+			}
+			for _, extra := range []int{0, 3} {
+				lda := k + 1 + extra
+				a := sPackBand(kL, kU, lda, n, n, aDense)
+				name := fmt.Sprintf("ul=%v,k=%v,lda=%v", ul, k, lda)
+				testSymMatVec(t, rnd, name, n, sym, a, func(alpha float32, x []float32, incX int, beta float32, y []float32, incY int) {
+					impl.Ssbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+				})
+			}
+		}
+	}
+}
+
+func SspmvTest(t *testing.T, impl Sspmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			aDense := randomSSlice(n*n, rnd)
+			sym := sSymDense(ul, n, aDense, max(1, n))
+			ap := sPackTri(ul, n, aDense)
+			testSymMatVec(t, rnd, fmt.Sprintf("ul=%v", ul), n, sym, ap, func(alpha float32, x []float32, incX int, beta float32, y []float32, incY int) {
+				impl.Sspmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+			})
+		}
+	}
+}
+
+// testSymMatVec checks that f computes y = alpha*A*x + beta*y, where A is the
+// n×n dense symmetric matrix sym stored by f in a.
+func testSymMatVec(t *testing.T, rnd *rand.Rand, name string, n int, sym, a []float32, f func(alpha float32, x []float32, incX int, beta float32, y []float32, incY int)) {
+	for _, incX := range []int{-3, 1, 2} {
+		x := makeSVector(randomSSlice(n, rnd), incX)
+		for _, incY := range []int{-2, 1, 4} {
+			for _, alpha := range []float32{0, 1, -1.3} {
+				for _, beta := range []float32{0, 1, 0.7} {
+					yd := randomSSlice(n, rnd)
+					if beta == 0 {
+						yd = sNaNSlice(n)
+					}
+					y := makeSVector(yd, incY)
+					want := sSliceCopy(y)
+					smv(blas.NoTrans, n, n, alpha, sym, max(1, n), x, incX, beta, want, incY)
+
+					aCopy := sSliceCopy(a)
+					xCopy := sSliceCopy(x)
+					f(alpha, x, incX, beta, y, incY)
+
+					prefix := fmt.Sprintf("%v,n=%v,incX=%v,incY=%v,alpha=%v,beta=%v", name, n, incX, incY, alpha, beta)
+					if !sSliceEqual(a, aCopy) {
+						t.Errorf("%v: unexpected modification of A", prefix)
+					}
+					if !sSliceEqual(x, xCopy) {
+						t.Errorf("%v: unexpected modification of x", prefix)
+					}
+					if !sSliceTolEqual(y, want, sAccTol) {
+						t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+					}
+				}
+			}
+		}
+	}
+}
+
+type Strmver interface {
+	Strmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int)
+}
+
+type Stbmver interface {
+	Stbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, x []float32, incX int)
+}
+
+type Stpmver interface {
+	Stpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int)
+}
+
+type Strsver interface {
+	Strsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int)
+}
+
+type Stbsver interface {
+	Stbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, x []float32, incX int)
+}
+
+type Stpsver interface {
+	Stpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int)
+}
+
+func StrmvTest(t *testing.T, impl Strmver) {
+	testSTriangular(t, false, false, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Strmv(ul, tA, d, n, a, lda, x, incX)
+	})
+}
+
+func StbmvTest(t *testing.T, impl Stbmver) {
+	testSTriangular(t, false, true, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Stbmv(ul, tA, d, n, k, a, lda, x, incX)
+	})
+}
+
+func StpmvTest(t *testing.T, impl Stpmver) {
+	testSTriangular(t, false, false, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Stpmv(ul, tA, d, n, ap, x, incX)
+	})
+}
+
+func StrsvTest(t *testing.T, impl Strsver) {
+	testSTriangular(t, true, false, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Strsv(ul, tA, d, n, a, lda, x, incX)
+	})
+}
+
+func StbsvTest(t *testing.T, impl Stbsver) {
+	testSTriangular(t, true, true, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Stbsv(ul, tA, d, n, k, a, lda, x, incX)
+	})
+}
+
+func StpsvTest(t *testing.T, impl Stpsver) {
+	testSTriangular(t, true, false, func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int) {
+		impl.Stpsv(ul, tA, d, n, ap, x, incX)
+	})
+}
+
+// testSTriangular checks that f computes x = op(A)*x, or solves op(A)*x = b
+// if solve is true, for a triangular matrix A. f is passed A in dense storage
+// in a, or in band storage if band is true, and in packed storage in ap.
+func testSTriangular(t *testing.T, solve, band bool, f func(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float32, lda int, ap []float32, x []float32, incX int)) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, test := range []struct {
+					n, k int
+				}{
+					{0, 0}, {1, 0}, {1, 3}, {3, 1}, {4, 0}, {4, 3}, {5, 2}, {7, 6}, {10, 3}, {10, 9},
+				} {
+					n, k := test.n, test.k
+					if !band {
+						k = max(0, n-1)
+					}
+					// For a unit diagonal the diagonal holds random values
+					// that must not be referenced, as does the opposite
+					// triangle in dense storage.
+					aDense := randomSTriangular(n, rnd)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if band && (j < i-k || j > i+k) {
+								aDense[i*n+j] = 0
+							}
+						}
+					}
+					tri, upper := sTriDense(ul, tA, d, n, aDense, max(1, n))
+					ap := sPackTri(ul, n, aDense)
+					for _, extra := range []int{0, 3} {
+						var a []float32
+						var lda int
+						if band {
+							kL, kU := 0, k
+							if ul == blas.Lower {
+								kL, kU = k, 0
+							}
+							lda = k + 1 + extra
+							a = sPackBand(kL, kU, lda, n, n, aDense)
+						} else {
+							lda = max(1, n+extra)
+							a = makeSGeneral(aDense, n, n, lda)
+						}
+						for _, incX := range []int{-3, 1, 2} {
+							x := makeSVector(randomSSlice(n, rnd), incX)
+							want := sSliceCopy(x)
+							if solve {
+								strsvRef(upper, n, tri, want, incX)
+							} else {
+								smv(blas.NoTrans, n, n, 1, tri, max(1, n), x, incX, 0, want, incX)
+							}
+
+							aCopy := sSliceCopy(a)
+							apCopy := sSliceCopy(ap)
+							f(ul, tA, d, n, k, a, lda, ap, x, incX)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,d=%v,n=%v,k=%v,lda=%v,incX=%v", ul, tA, d, n, k, lda, incX)
+							if !sSliceEqual(a, aCopy) || !sSliceEqual(ap, apCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !sSliceTolEqual(x, want, sAccTol) {
+								t.Errorf("%v: unexpected x\nwant %v\ngot  %v", prefix, want, x)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Sgerer interface {
+	Sger(m, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int)
+}
+
+func SgerTest(t *testing.T, impl Sgerer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0}, {0, 3}, {3, 0}, {1, 1}, {2, 3}, {3, 2}, {4, 4}, {7, 5}, {10, 13},
+	} {
+		m, n := test.m, test.n
+		for _, extra := range []int{0, 3} {
+			lda := max(1, n+extra)
+			for _, incX := range []int{-3, 1, 2} {
+				x := makeSVector(randomSSlice(m, rnd), incX)
+				for _, incY := range []int{-2, 1, 4} {
+					y := makeSVector(randomSSlice(n, rnd), incY)
+					for _, alpha := range []float32{0, 1, -1.3} {
+						a := makeSGeneral(randomSSlice(m*n, rnd), m, n, lda)
+						want := sSliceCopy(a)
+						// A += alpha * x * y^T is computed as
+						// A = alpha * X * Y + A for an m×1 X and a 1×n Y.
+						xm := make([]float32, m)
+						for i := range xm {
+							xm[i] = x[zidx(i, m, incX)]
+						}
+						ym := make([]float32, n)
+						for j := range ym {
+							ym[j] = y[zidx(j, n, incY)]
+						}
+						smm(blas.NoTrans, blas.NoTrans, m, n, 1, alpha, xm, 1, ym, max(1, n), 1, want, lda)
+
+						xCopy := sSliceCopy(x)
+						yCopy := sSliceCopy(y)
+						impl.Sger(m, n, alpha, x, incX, y, incY, a, lda)
+
+						prefix := fmt.Sprintf("m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v", m, n, lda, incX, incY, alpha)
+						if !sSliceEqual(x, xCopy) {
+							t.Errorf("%v: unexpected modification of x", prefix)
+						}
+						if !sSliceEqual(y, yCopy) {
+							t.Errorf("%v: unexpected modification of y", prefix)
+						}
+						if !sSliceTolEqual(a, want, sAccTol) {
+							t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, a)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Ssyrer interface {
+	Ssyr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int)
+}
+
+type Ssprer interface {
+	Sspr(ul blas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32)
+}
+
+type Ssyr2er interface {
+	Ssyr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int)
+}
+
+type Sspr2er interface {
+	Sspr2(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, ap []float32)
+}
+
+func SsyrTest(t *testing.T, impl Ssyrer) {
+	testSSymUpdate(t, false, false, func(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int, ap []float32) {
+		impl.Ssyr(ul, n, alpha, x, incX, a, lda)
+	})
+}
+
+func SsprTest(t *testing.T, impl Ssprer) {
+	testSSymUpdate(t, false, true, func(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int, ap []float32) {
+		impl.Sspr(ul, n, alpha, x, incX, ap)
+	})
+}
+
+func Ssyr2Test(t *testing.T, impl Ssyr2er) {
+	testSSymUpdate(t, true, false, func(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int, ap []float32) {
+		impl.Ssyr2(ul, n, alpha, x, incX, y, incY, a, lda)
+	})
+}
+
+func Sspr2Test(t *testing.T, impl Sspr2er) {
+	testSSymUpdate(t, true, true, func(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int, ap []float32) {
+		impl.Sspr2(ul, n, alpha, x, incX, y, incY, ap)
+	})
+}
+
+// testSSymUpdate checks that f computes the symmetric rank-2 update
+//  A += alpha * (x * y^T + y * x^T)
+// if rank2 is true, and the rank-1 update
+//  A += alpha * x * x^T
+// otherwise. f is passed A in dense storage in a, or in packed storage in ap
+// if packed is true.
+func testSSymUpdate(t *testing.T, rank2, packed bool, f func(ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int, ap []float32)) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				if packed {
+					lda = max(1, n)
+				}
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeSVector(randomSSlice(n, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						y := makeSVector(randomSSlice(n, rnd), incY)
+						for _, alpha := range []float32{0, 1, -1.3} {
+							a := makeSGeneral(randomSSlice(n*n, rnd), n, n, lda)
+							want := zFromS(a)
+							// zher2Ref with real data computes
+							// A += alpha * (x * y^T + y * x^T).
+							if rank2 {
+								zher2Ref(ul, n, complex(float64(alpha), 0), zFromS(x), incX, zFromS(y), incY, want, lda)
+							} else {
+								zher2Ref(ul, n, complex(float64(alpha)/2, 0), zFromS(x), incX, zFromS(x), incX, want, lda)
+							}
+
+							xCopy := sSliceCopy(x)
+							yCopy := sSliceCopy(y)
+							var ap []float32
+							if packed {
+								ap = sPackTri(ul, n, a)
+								f(ul, n, alpha, x, incX, y, incY, nil, 0, ap)
+							} else {
+								f(ul, n, alpha, x, incX, y, incY, a, lda, nil)
+							}
+
+							prefix := fmt.Sprintf("ul=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v", ul, n, lda, incX, incY, alpha)
+							if !sSliceEqual(x, xCopy) {
+								t.Errorf("%v: unexpected modification of x", prefix)
+							}
+							if !sSliceEqual(y, yCopy) {
+								t.Errorf("%v: unexpected modification of y", prefix)
+							}
+							if packed {
+								if wantP := sPackTri(ul, n, sFromZ(want)); !sSliceTolEqual(ap, wantP, sAccTol) {
+									t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, wantP, ap)
+								}
+							} else if !sSliceTolEqual(a, sFromZ(want), sAccTol) {
+								t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, sFromZ(want), a)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// The float32 Level 3 tests compare against reference results computed in
+// double precision from the same float32 data.
+
+type Sgemmer interface {
+	Sgemm(tA, tB blas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)
+}
+
+func SgemmTest(t *testing.T, impl Sgemmer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				m, n, k int
+			}{
+				{0, 0, 0}, {0, 3, 2}, {3, 0, 2}, {2, 3, 0},
+				{1, 1, 1}, {3, 4, 5}, {7, 2, 3}, {5, 6, 9},
+				// Large enough to be partitioned into blocks.
+				{130, 131, 20},
+				// Large enough to use packed panels.
+				{131, 129, 133},
+			} {
+				m, n, k := test.m, test.n, test.k
+				rowA, colA := m, k
+				if tA != blas.NoTrans {
+					rowA, colA = k, m
+				}
+				rowB, colB := k, n
+				if tB != blas.NoTrans {
+					rowB, colB = n, k
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, colA+extra)
+					ldb := max(1, colB+extra)
+					ldc := max(1, n+extra)
+					a := makeSGeneral(randomSSlice(rowA*colA, rnd), rowA, colA, lda)
+					b := makeSGeneral(randomSSlice(rowB*colB, rnd), rowB, colB, ldb)
+					for _, alpha := range []float32{0, 1, -1.3} {
+						for _, beta := range []float32{0, 1, 0.7} {
+							cd := randomSSlice(m*n, rnd)
+							if beta == 0 {
+								cd = sNaNSlice(m * n)
+							}
+							c := makeSGeneral(cd, m, n, ldc)
+							want := sSliceCopy(c)
+							smm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, want, ldc)
+
+							aCopy := sSliceCopy(a)
+							bCopy := sSliceCopy(b)
+							impl.Sgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("tA=%v,tB=%v,m=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", tA, tB, m, n, k, extra, alpha, beta)
+							if !sSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !sSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !sSliceTolEqual(c, want, sAccTol) {
+								t.Errorf("%v: unexpected C", prefix)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Ssymmer interface {
+	Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)
+}
+
+func SsymmTest(t *testing.T, impl Ssymmer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, test := range []struct {
+				m, n int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+			} {
+				m, n := test.m, test.n
+				na := m
+				if s == blas.Right {
+					na = n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, na+extra)
+					ldb := max(1, n+extra+1)
+					ldc := max(1, n+extra)
+					// The opposite triangle holds random values that must
+					// not be referenced.
+					a := makeSGeneral(randomSSlice(na*na, rnd), na, na, lda)
+					sym := sSymDense(ul, na, a, lda)
+					b := makeSGeneral(randomSSlice(m*n, rnd), m, n, ldb)
+					for _, alpha := range []float32{0, 1, -1.3} {
+						for _, beta := range []float32{0, 1, 0.7} {
+							cd := randomSSlice(m*n, rnd)
+							if beta == 0 {
+								cd = sNaNSlice(m * n)
+							}
+							c := makeSGeneral(cd, m, n, ldc)
+							want := sSliceCopy(c)
+							if s == blas.Left {
+								smm(blas.NoTrans, blas.NoTrans, m, n, m, alpha, sym, max(1, m), b, ldb, beta, want, ldc)
+							} else {
+								smm(blas.NoTrans, blas.NoTrans, m, n, n, alpha, b, ldb, sym, max(1, n), beta, want, ldc)
+							}
+
+							aCopy := sSliceCopy(a)
+							bCopy := sSliceCopy(b)
+							impl.Ssymm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("s=%v,ul=%v,m=%v,n=%v,extra=%v,alpha=%v,beta=%v", s, ul, m, n, extra, alpha, beta)
+							if !sSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !sSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !sSliceTolEqual(c, want, sAccTol) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Ssyrker interface {
+	Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int)
+}
+
+type Ssyr2ker interface {
+	Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)
+}
+
+func SsyrkTest(t *testing.T, impl Ssyrker) {
+	testSSymRankK(t, false, func(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+		impl.Ssyrk(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+	})
+}
+
+func Ssyr2kTest(t *testing.T, impl Ssyr2ker) {
+	testSSymRankK(t, true, func(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+		impl.Ssyr2k(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+	})
+}
+
+// testSSymRankK checks that f computes the symmetric rank-2k update
+//  C = alpha * (op(A) * op(B)^T + op(B) * op(A)^T) + beta * C
+// if rank2 is true, and the rank-k update
+//  C = alpha * op(A) * op(A)^T + beta * C
+// otherwise, where op(X) is X if tA is blas.NoTrans and X^T if it is
+// blas.Trans.
+func testSSymRankK(t *testing.T, rank2 bool, f func(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int)) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				n, k int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {3, 2}, {4, 7}, {7, 3}, {10, 10},
+			} {
+				n, k := test.n, test.k
+				row, col := n, k
+				if tA != blas.NoTrans {
+					row, col = k, n
+				}
+				tL, tR := blas.NoTrans, blas.Trans
+				if tA != blas.NoTrans {
+					tL, tR = blas.Trans, blas.NoTrans
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, col+extra)
+					ldb := max(1, col+extra+1)
+					ldc := max(1, n+extra)
+					a := makeSGeneral(randomSSlice(row*col, rnd), row, col, lda)
+					var b []float32
+					if rank2 {
+						b = makeSGeneral(randomSSlice(row*col, rnd), row, col, ldb)
+					}
+					for _, alpha := range []float32{0, 1, -1.3} {
+						for _, beta := range []float32{0, 1, 0.7} {
+							cd := randomSSlice(n*n, rnd)
+							if beta == 0 {
+								cd = sNaNSlice(n * n)
+							}
+							c := makeSGeneral(cd, n, n, ldc)
+
+							p := make([]float32, n*n)
+							if rank2 {
+								smm(tL, tR, n, n, k, alpha, a, lda, b, ldb, 0, p, max(1, n))
+								smm(tL, tR, n, n, k, alpha, b, ldb, a, lda, 1, p, max(1, n))
+							} else {
+								smm(tL, tR, n, n, k, alpha, a, lda, a, lda, 0, p, max(1, n))
+							}
+							wantZ := zFromS(c)
+							zhermUpdateRef(ul, n, zFromS(p), float64(beta), wantZ, ldc)
+							want := sFromZ(wantZ)
+
+							aCopy := sSliceCopy(a)
+							bCopy := sSliceCopy(b)
+							f(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", ul, tA, n, k, extra, alpha, beta)
+							if !sSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !sSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !sSliceTolEqual(c, want, sAccTol) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+type Strmmer interface {
+	Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int)
+}
+
+type Strsmer interface {
+	Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int)
+}
+
+func StrmmTest(t *testing.T, impl Strmmer) {
+	testSTriangularMatrix(t, false, impl.Strmm)
+}
+
+func StrsmTest(t *testing.T, impl Strsmer) {
+	testSTriangularMatrix(t, true, impl.Strsm)
+}
+
+// testSTriangularMatrix checks that f computes
+//  B = alpha * op(A) * B  if s == blas.Left
+//  B = alpha * B * op(A)  if s == blas.Right
+// or, if solve is true, solves op(A) * X = alpha * B or X * op(A) = alpha * B
+// for X, where A is triangular.
+func testSTriangularMatrix(t *testing.T, solve bool, f func(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int)) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, test := range []struct {
+						m, n int
+					}{
+						{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+					} {
+						m, n := test.m, test.n
+						na := m
+						if s == blas.Right {
+							na = n
+						}
+						for _, extra := range []int{0, 3} {
+							lda := max(1, na+extra)
+							ldb := max(1, n+extra+1)
+							// The opposite triangle of A holds random values
+							// and, for a unit diagonal, so does the diagonal.
+							// Neither may be referenced.
+							a := makeSGeneral(randomSTriangular(na, rnd), na, na, lda)
+							for _, alpha := range []float32{0, 1, -1.3} {
+								b := makeSGeneral(randomSSlice(m*n, rnd), m, n, ldb)
+								var want []float32
+								if solve {
+									wantZ := zFromS(b)
+									ztrsmRef(s, ul, tA, d, m, n, complex(float64(alpha), 0), zFromS(a), lda, wantZ, ldb)
+									want = sFromZ(wantZ)
+								} else {
+									want = sSliceCopy(b)
+									tri, _ := sTriDense(ul, tA, d, na, a, lda)
+									if s == blas.Left {
+										smm(blas.NoTrans, blas.NoTrans, m, n, m, alpha, tri, max(1, m), b, ldb, 0, want, ldb)
+									} else {
+										smm(blas.NoTrans, blas.NoTrans, m, n, n, alpha, b, ldb, tri, max(1, n), 0, want, ldb)
+									}
+								}
+
+								aCopy := sSliceCopy(a)
+								f(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+
+								prefix := fmt.Sprintf("s=%v,ul=%v,tA=%v,d=%v,m=%v,n=%v,extra=%v,alpha=%v", s, ul, tA, d, m, n, extra, alpha)
+								if !sSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !sSliceTolEqual(b, want, sAccTol) {
+									t.Errorf("%v: unexpected B\nwant %v\ngot  %v", prefix, want, b)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zgbmver interface {
+	Zgbmv(tA blas.Transpose, m, n, kL, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZgbmvTest(t *testing.T, impl Zgbmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, test := range []struct {
+			m, n, kL, kU int
+		}{
+			{0, 0, 0, 0}, {0, 3, 1, 1}, {3, 0, 0, 2},
+			{1, 1, 0, 0}, {1, 1, 1, 2},
+			{4, 4, 0, 0}, {4, 4, 1, 2}, {4, 4, 3, 3},
+			{5, 3, 2, 0}, {3, 5, 0, 2}, {7, 6, 1, 3}, {6, 9, 4, 1},
+		} {
+			m, n, kL, kU := test.m, test.n, test.kL, test.kU
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			// Generate a dense matrix that is zero outside the band.
+			aDense := randomZSlice(m*n, rnd)
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					if j < i-kL || j > i+kU {
+						aDense[i*n+j] = 0
+					}
+				}
+			}
+			for _, extra := range []int{0, 3} {
+				lda := kL + kU + 1 + extra
+				a := zPackBand(kL, kU, lda, m, n, aDense)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeZVector(randomZSlice(lenX, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+							for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+								yd := randomZSlice(lenY, rnd)
+								if beta == 0 {
+									yd = zNaNSlice(lenY)
+								}
+								y := makeZVector(yd, incY)
+								want := zSliceCopy(y)
+								zmv(tA, m, n, alpha, aDense, max(1, n), x, incX, beta, want, incY)
+
+								aCopy := zSliceCopy(a)
+								xCopy := zSliceCopy(x)
+								impl.Zgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("tA=%v,m=%v,n=%v,kL=%v,kU=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v",
+									tA, m, n, kL, kU, lda, incX, incY, alpha, beta)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !zSliceTolEqual(y, want) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zgemmer interface {
+	Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZgemmTest(t *testing.T, impl Zgemmer) {
+	rnd := rand.New(rand.NewSource(1))
+	transposes := []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans}
+	for _, tA := range transposes {
+		for _, tB := range transposes {
+			for _, test := range []struct {
+				m, n, k int
+			}{
+				{0, 0, 0}, {0, 3, 2}, {3, 0, 2}, {2, 3, 0},
+				{1, 1, 1}, {3, 4, 5}, {7, 2, 3}, {5, 6, 9},
+				// Large enough to be partitioned into blocks.
+				{130, 131, 20},
+			} {
+				m, n, k := test.m, test.n, test.k
+				rowA, colA := m, k
+				if tA != blas.NoTrans {
+					rowA, colA = k, m
+				}
+				rowB, colB := k, n
+				if tB != blas.NoTrans {
+					rowB, colB = n, k
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, colA+extra)
+					ldb := max(1, colB+extra)
+					ldc := max(1, n+extra)
+					a := makeZGeneral(randomZSlice(rowA*colA, rnd), rowA, colA, lda)
+					b := makeZGeneral(randomZSlice(rowB*colB, rnd), rowB, colB, ldb)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+							cd := randomZSlice(m*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(m * n)
+							}
+							c := makeZGeneral(cd, m, n, ldc)
+							want := zSliceCopy(c)
+							zmm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, want, ldc)
+
+							aCopy := zSliceCopy(a)
+							bCopy := zSliceCopy(b)
+							impl.Zgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("tA=%v,tB=%v,m=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", tA, tB, m, n, k, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C", prefix)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zgemver interface {
+	Zgemv(tA blas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZgemvTest(t *testing.T, impl Zgemver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, test := range []struct {
+			m, n int
+		}{
+			{0, 0}, {0, 3}, {3, 0}, {1, 1}, {2, 3}, {3, 2}, {4, 4}, {7, 5}, {10, 13},
+		} {
+			m, n := test.m, test.n
+			lenX, lenY := n, m
+			if tA != blas.NoTrans {
+				lenX, lenY = m, n
+			}
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				a := makeZGeneral(randomZSlice(m*n, rnd), m, n, lda)
+				for _, incX := range []int{-4, 1, 2} {
+					x := makeZVector(randomZSlice(lenX, rnd), incX)
+					for _, incY := range []int{-2, 1, 3} {
+						for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+							for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+								yd := randomZSlice(lenY, rnd)
+								if beta == 0 {
+									yd = zNaNSlice(lenY)
+								}
+								y := makeZVector(yd, incY)
+								want := zSliceCopy(y)
+								zmv(tA, m, n, alpha, a, lda, x, incX, beta, want, incY)
+
+								aCopy := zSliceCopy(a)
+								xCopy := zSliceCopy(x)
+								impl.Zgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("tA=%v,m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v", tA, m, n, lda, incX, incY, alpha, beta)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !zSliceTolEqual(y, want) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Check argument validation.
+	a := make([]complex128, 6)
+	x := make([]complex128, 3)
+	y := make([]complex128, 2)
+	for _, test := range []struct {
+		name string
+		tA   blas.Transpose
+		m, n int
+		a    []complex128
+		lda  int
+		x    []complex128
+		incX int
+		y    []complex128
+		incY int
+	}{
+		{"BadTranspose", 'X', 2, 3, a, 3, x, 1, y, 1},
+		{"MLT0", blas.NoTrans, -1, 3, a, 3, x, 1, y, 1},
+		{"NLT0", blas.NoTrans, 2, -1, a, 3, x, 1, y, 1},
+		{"BadLda", blas.NoTrans, 2, 3, a, 2, x, 1, y, 1},
+		{"ShortA", blas.NoTrans, 2, 3, a[:5], 3, x, 1, y, 1},
+		{"ZeroIncX", blas.NoTrans, 2, 3, a, 3, x, 0, y, 1},
+		{"ZeroIncY", blas.NoTrans, 2, 3, a, 3, x, 1, y, 0},
+		{"ShortX", blas.NoTrans, 2, 3, a, 3, x[:2], 1, y, 1},
+		{"ShortY", blas.NoTrans, 2, 3, a, 3, x, 1, y[:1], 1},
+		{"ShortYTrans", blas.ConjTrans, 2, 3, a, 3, x, 1, y, 1},
+	} {
+		test := test
+		f := func() {
+			impl.Zgemv(test.tA, test.m, test.n, 1, test.a, test.lda, test.x, test.incX, 0, test.y, test.incY)
+		}
+		if !panics(f) {
+			t.Errorf("%v: no panic", test.name)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package testblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zgercer interface {
+	Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+func ZgercTest(t *testing.T, impl Zgercer) {
+	zgerTest(t, impl.Zgerc, blas.ConjTrans)
+}
@@ -0,0 +1,81 @@
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zgeruer interface {
+	Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+func ZgeruTest(t *testing.T, impl Zgeruer) {
+	zgerTest(t, impl.Zgeru, blas.Trans)
+}
+
+// zgerTest tests the rank-one update ger, which computes
+//  A += alpha * x * y^T  if tY == blas.Trans
+//  A += alpha * x * y^H  if tY == blas.ConjTrans
+func zgerTest(t *testing.T, ger func(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int), tY blas.Transpose) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+	} {
+		m, n := test.m, test.n
+		for _, extra := range []int{0, 3} {
+			lda := max(1, n+extra)
+			for _, incX := range []int{-3, 1, 2} {
+				x := makeZVector(randomZSlice(m, rnd), incX)
+				for _, incY := range []int{-2, 1, 4} {
+					y := makeZVector(randomZSlice(n, rnd), incY)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						a := makeZGeneral(randomZSlice(m*n, rnd), m, n, lda)
+						want := zSliceCopy(a)
+						zgerRef(tY, m, n, alpha, x, incX, y, incY, want, lda)
+
+						xCopy := zSliceCopy(x)
+						yCopy := zSliceCopy(y)
+						ger(m, n, alpha, x, incX, y, incY, a, lda)
+
+						prefix := fmt.Sprintf("m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v", m, n, lda, incX, incY, alpha)
+						if !zSliceEqual(x, xCopy) {
+							t.Errorf("%v: unexpected modification of x", prefix)
+						}
+						if !zSliceEqual(y, yCopy) {
+							t.Errorf("%v: unexpected modification of y", prefix)
+						}
+						if !zSliceTolEqual(a, want) {
+							t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, a)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zgerRef performs the rank-one update
+//  A += alpha * x * y^T  if tY == blas.Trans
+//  A += alpha * x * y^H  if tY == blas.ConjTrans
+// of the m×n matrix A using a naive algorithm.
+func zgerRef(tY blas.Transpose, m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	if m == 0 || n == 0 || alpha == 0 {
+		return
+	}
+	for i := 0; i < m; i++ {
+		xi := x[zidx(i, m, incX)]
+		for j := 0; j < n; j++ {
+			yj := y[zidx(j, n, incY)]
+			if tY == blas.ConjTrans {
+				yj = cmplx.Conj(yj)
+			}
+			a[i*lda+j] += alpha * xi * yj
+		}
+	}
+}
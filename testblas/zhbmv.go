@@ -0,0 +1,76 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhbmver interface {
+	Zhbmv(ul blas.Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhbmvTest(t *testing.T, impl Zhbmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, k int
+		}{
+			{0, 0}, {0, 2}, {1, 0}, {1, 3}, {3, 1}, {4, 0}, {4, 3}, {5, 2}, {7, 3}, {10, 8},
+		} {
+			n, k := test.n, test.k
+			// Generate a dense matrix that is zero outside the band. The
+			// diagonal has non-zero imaginary parts that must be ignored.
+			aDense := randomZSlice(n*n, rnd)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if j < i-k || j > i+k {
+						aDense[i*n+j] = 0
+					}
+				}
+			}
+			h := zHermDense(ul, n, aDense, max(1, n))
+			kL, kU := 0, k
+			if ul == blas.Lower {
+				kL, kU = k, 0
+			}
+			for _, extra := range []int{0, 3} {
+				lda := k + 1 + extra
+				a := zPackBand(kL, kU, lda, n, n, aDense)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeZVector(randomZSlice(n, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+							for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+								yd := randomZSlice(n, rnd)
+								if beta == 0 {
+									yd = zNaNSlice(n)
+								}
+								y := makeZVector(yd, incY)
+								want := zSliceCopy(y)
+								zmv(blas.NoTrans, n, n, alpha, h, max(1, n), x, incX, beta, want, incY)
+
+								aCopy := zSliceCopy(a)
+								xCopy := zSliceCopy(x)
+								impl.Zhbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("ul=%v,n=%v,k=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v", ul, n, k, lda, incX, incY, alpha, beta)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !zSliceTolEqual(y, want) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package testblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhemmer interface {
+	Zhemm(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZhemmTest(t *testing.T, impl Zhemmer) {
+	zsymmTest(t, impl.Zhemm, true)
+}
@@ -0,0 +1,60 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhemver interface {
+	Zhemv(ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhemvTest(t *testing.T, impl Zhemver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				// The diagonal of A has non-zero imaginary parts and the
+				// opposite triangle holds random values. Neither may be
+				// referenced by Zhemv.
+				a := makeZGeneral(randomZSlice(n*n, rnd), n, n, lda)
+				h := zHermDense(ul, n, a, lda)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeZVector(randomZSlice(n, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+							for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+								yd := randomZSlice(n, rnd)
+								if beta == 0 {
+									yd = zNaNSlice(n)
+								}
+								y := makeZVector(yd, incY)
+								want := zSliceCopy(y)
+								zmv(blas.NoTrans, n, n, alpha, h, max(1, n), x, incX, beta, want, incY)
+
+								aCopy := zSliceCopy(a)
+								xCopy := zSliceCopy(x)
+								impl.Zhemv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+
+								prefix := fmt.Sprintf("ul=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v", ul, n, lda, incX, incY, alpha, beta)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceEqual(x, xCopy) {
+									t.Errorf("%v: unexpected modification of x", prefix)
+								}
+								if !zSliceTolEqual(y, want) {
+									t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zherer interface {
+	Zher(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int)
+}
+
+func ZherTest(t *testing.T, impl Zherer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeZVector(randomZSlice(n, rnd), incX)
+					for _, alpha := range []float64{0, 1, -0.8} {
+						// The diagonal of A has non-zero imaginary parts
+						// that must be ignored and set to zero.
+						a := makeZGeneral(randomZSlice(n*n, rnd), n, n, lda)
+						want := zSliceCopy(a)
+						zherRef(ul, n, alpha, x, incX, want, lda)
+
+						xCopy := zSliceCopy(x)
+						impl.Zher(ul, n, alpha, x, incX, a, lda)
+
+						prefix := fmt.Sprintf("ul=%v,n=%v,lda=%v,incX=%v,alpha=%v", ul, n, lda, incX, alpha)
+						if !zSliceEqual(x, xCopy) {
+							t.Errorf("%v: unexpected modification of x", prefix)
+						}
+						if !zSliceTolEqual(a, want) {
+							t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, a)
+						}
+						if alpha != 0 {
+							for i := 0; i < n; i++ {
+								if imag(a[i*lda+i]) != 0 {
+									t.Errorf("%v: non-zero imaginary part of diagonal element %v", prefix, i)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zherRef performs the Hermitian rank-1 update
+//  A += alpha * x * x^H
+// on the ul triangle of the n×n matrix A using a naive algorithm.
+func zherRef(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		xi := x[zidx(i, n, incX)]
+		for j := jStart; j < jEnd; j++ {
+			u := complex(alpha, 0) * xi * cmplx.Conj(x[zidx(j, n, incX)])
+			if i == j {
+				a[i*lda+j] = complex(real(a[i*lda+j])+real(u), 0)
+			} else {
+				a[i*lda+j] += u
+			}
+		}
+	}
+}
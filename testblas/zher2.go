@@ -0,0 +1,87 @@
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zher2er interface {
+	Zher2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+func Zher2Test(t *testing.T, impl Zher2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, extra := range []int{0, 3} {
+				lda := max(1, n+extra)
+				for _, incX := range []int{-3, 1, 2} {
+					x := makeZVector(randomZSlice(n, rnd), incX)
+					for _, incY := range []int{-2, 1, 4} {
+						y := makeZVector(randomZSlice(n, rnd), incY)
+						for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+							// The diagonal of A has non-zero imaginary parts
+							// that must be ignored and set to zero.
+							a := makeZGeneral(randomZSlice(n*n, rnd), n, n, lda)
+							want := zSliceCopy(a)
+							zher2Ref(ul, n, alpha, x, incX, y, incY, want, lda)
+
+							xCopy := zSliceCopy(x)
+							yCopy := zSliceCopy(y)
+							impl.Zher2(ul, n, alpha, x, incX, y, incY, a, lda)
+
+							prefix := fmt.Sprintf("ul=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v", ul, n, lda, incX, incY, alpha)
+							if !zSliceEqual(x, xCopy) {
+								t.Errorf("%v: unexpected modification of x", prefix)
+							}
+							if !zSliceEqual(y, yCopy) {
+								t.Errorf("%v: unexpected modification of y", prefix)
+							}
+							if !zSliceTolEqual(a, want) {
+								t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, a)
+							}
+							if alpha != 0 {
+								for i := 0; i < n; i++ {
+									if imag(a[i*lda+i]) != 0 {
+										t.Errorf("%v: non-zero imaginary part of diagonal element %v", prefix, i)
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zher2Ref performs the Hermitian rank-2 update
+//  A += alpha * x * y^H + conj(alpha) * y * x^H
+// on the ul triangle of the n×n matrix A using a naive algorithm.
+func zher2Ref(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	if n == 0 || alpha == 0 {
+		return
+	}
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		xi := x[zidx(i, n, incX)]
+		yi := y[zidx(i, n, incY)]
+		for j := jStart; j < jEnd; j++ {
+			xj := x[zidx(j, n, incX)]
+			yj := y[zidx(j, n, incY)]
+			u := alpha*xi*cmplx.Conj(yj) + cmplx.Conj(alpha)*yi*cmplx.Conj(xj)
+			if i == j {
+				a[i*lda+j] = complex(real(a[i*lda+j])+real(u), 0)
+			} else {
+				a[i*lda+j] += u
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zher2ker interface {
+	Zher2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int)
+}
+
+func Zher2kTest(t *testing.T, impl Zher2ker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.ConjTrans} {
+			for _, test := range []struct {
+				n, k int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {3, 2}, {4, 7}, {7, 3}, {10, 10},
+			} {
+				n, k := test.n, test.k
+				row, col := n, k
+				if tA == blas.ConjTrans {
+					row, col = k, n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, col+extra)
+					ldb := max(1, col+extra+1)
+					ldc := max(1, n+extra)
+					a := makeZGeneral(randomZSlice(row*col, rnd), row, col, lda)
+					b := makeZGeneral(randomZSlice(row*col, rnd), row, col, ldb)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []float64{0, 1, 0.7} {
+							// The diagonal of C has non-zero imaginary
+							// parts that must be ignored and set to zero.
+							cd := randomZSlice(n*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(n * n)
+							}
+							c := makeZGeneral(cd, n, n, ldc)
+							want := zSliceCopy(c)
+							zher2kRef(ul, tA, n, k, alpha, a, lda, b, ldb, beta, want, ldc)
+
+							aCopy := zSliceCopy(a)
+							bCopy := zSliceCopy(b)
+							impl.Zher2k(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", ul, tA, n, k, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zher2kRef performs the Hermitian rank-2k update
+//  C = alpha * A * B^H + conj(alpha) * B * A^H + beta * C  if tA == blas.NoTrans
+//  C = alpha * A^H * B + conj(alpha) * B^H * A + beta * C  if tA == blas.ConjTrans
+// on the ul triangle of the n×n matrix C using a naive algorithm.
+func zher2kRef(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	tL, tR := blas.NoTrans, blas.ConjTrans
+	if tA == blas.ConjTrans {
+		tL, tR = blas.ConjTrans, blas.NoTrans
+	}
+	p := make([]complex128, n*n)
+	zmm(tL, tR, n, n, k, alpha, a, lda, b, ldb, 0, p, n)
+	zmm(tL, tR, n, n, k, cmplx.Conj(alpha), b, ldb, a, lda, 1, p, n)
+	zhermUpdateRef(ul, n, p, beta, c, ldc)
+}
@@ -0,0 +1,104 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zherker interface {
+	Zherk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int)
+}
+
+func ZherkTest(t *testing.T, impl Zherker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.ConjTrans} {
+			for _, test := range []struct {
+				n, k int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {3, 2}, {4, 7}, {7, 3}, {10, 10},
+			} {
+				n, k := test.n, test.k
+				rowA, colA := n, k
+				if tA == blas.ConjTrans {
+					rowA, colA = k, n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, colA+extra)
+					ldc := max(1, n+extra)
+					a := makeZGeneral(randomZSlice(rowA*colA, rnd), rowA, colA, lda)
+					for _, alpha := range []float64{0, 1, -0.8} {
+						for _, beta := range []float64{0, 1, 0.7} {
+							// The diagonal of C has non-zero imaginary
+							// parts that must be ignored and set to zero.
+							cd := randomZSlice(n*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(n * n)
+							}
+							c := makeZGeneral(cd, n, n, ldc)
+							want := zSliceCopy(c)
+							zherkRef(ul, tA, n, k, alpha, a, lda, beta, want, ldc)
+
+							aCopy := zSliceCopy(a)
+							impl.Zherk(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", ul, tA, n, k, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zherkRef performs the Hermitian rank-k update
+//  C = alpha * A * A^H + beta * C  if tA == blas.NoTrans
+//  C = alpha * A^H * A + beta * C  if tA == blas.ConjTrans
+// on the ul triangle of the n×n matrix C using a naive algorithm.
+func zherkRef(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	p := make([]complex128, n*n)
+	if tA == blas.NoTrans {
+		zmm(blas.NoTrans, blas.ConjTrans, n, n, k, complex(alpha, 0), a, lda, a, lda, 0, p, n)
+	} else {
+		zmm(blas.ConjTrans, blas.NoTrans, n, n, k, complex(alpha, 0), a, lda, a, lda, 0, p, n)
+	}
+	zhermUpdateRef(ul, n, p, beta, c, ldc)
+}
+
+// zhermUpdateRef sets the ul triangle of C to p + beta*C, treating the
+// diagonal of both as real. C is not read when beta is zero.
+func zhermUpdateRef(ul blas.Uplo, n int, p []complex128, beta float64, c []complex128, ldc int) {
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		for j := jStart; j < jEnd; j++ {
+			if i == j {
+				d := real(p[i*n+i])
+				if beta != 0 {
+					d += beta * real(c[i*ldc+i])
+				}
+				c[i*ldc+i] = complex(d, 0)
+				continue
+			}
+			v := p[i*n+j]
+			if beta != 0 {
+				v += complex(beta, 0) * c[i*ldc+j]
+			}
+			c[i*ldc+j] = v
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhpmver interface {
+	Zhpmv(ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZhpmvTest(t *testing.T, impl Zhpmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			// The diagonal of A has non-zero imaginary parts that must be
+			// ignored.
+			aDense := randomZSlice(n*n, rnd)
+			h := zHermDense(ul, n, aDense, max(1, n))
+			ap := zPackTri(ul, n, aDense)
+			for _, incX := range []int{-3, 1, 2} {
+				x := makeZVector(randomZSlice(n, rnd), incX)
+				for _, incY := range []int{-2, 1, 4} {
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []complex128{0, 1, -0.7 + 0.2i} {
+							yd := randomZSlice(n, rnd)
+							if beta == 0 {
+								yd = zNaNSlice(n)
+							}
+							y := makeZVector(yd, incY)
+							want := zSliceCopy(y)
+							zmv(blas.NoTrans, n, n, alpha, h, max(1, n), x, incX, beta, want, incY)
+
+							apCopy := zSliceCopy(ap)
+							xCopy := zSliceCopy(x)
+							impl.Zhpmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+
+							prefix := fmt.Sprintf("ul=%v,n=%v,incX=%v,incY=%v,alpha=%v,beta=%v", ul, n, incX, incY, alpha, beta)
+							if !zSliceEqual(ap, apCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceEqual(x, xCopy) {
+								t.Errorf("%v: unexpected modification of x", prefix)
+							}
+							if !zSliceTolEqual(y, want) {
+								t.Errorf("%v: unexpected y\nwant %v\ngot  %v", prefix, want, y)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhprer interface {
+	Zhpr(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128)
+}
+
+func ZhprTest(t *testing.T, impl Zhprer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, incX := range []int{-3, 1, 2} {
+				x := makeZVector(randomZSlice(n, rnd), incX)
+				for _, alpha := range []float64{0, 1, -0.8} {
+					// The diagonal of A has non-zero imaginary parts
+					// that must be ignored and set to zero.
+					aDense := randomZSlice(n*n, rnd)
+					ap := zPackTri(ul, n, aDense)
+					zherRef(ul, n, alpha, x, incX, aDense, max(1, n))
+					want := zPackTri(ul, n, aDense)
+
+					xCopy := zSliceCopy(x)
+					impl.Zhpr(ul, n, alpha, x, incX, ap)
+
+					prefix := fmt.Sprintf("ul=%v,n=%v,incX=%v,alpha=%v", ul, n, incX, alpha)
+					if !zSliceEqual(x, xCopy) {
+						t.Errorf("%v: unexpected modification of x", prefix)
+					}
+					if !zSliceTolEqual(ap, want) {
+						t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, ap)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zhpr2er interface {
+	Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128)
+}
+
+func Zhpr2Test(t *testing.T, impl Zhpr2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+			for _, incX := range []int{-3, 1, 2} {
+				x := makeZVector(randomZSlice(n, rnd), incX)
+				for _, incY := range []int{-2, 1, 4} {
+					y := makeZVector(randomZSlice(n, rnd), incY)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						// The diagonal of A has non-zero imaginary parts
+						// that must be ignored and set to zero.
+						aDense := randomZSlice(n*n, rnd)
+						ap := zPackTri(ul, n, aDense)
+						zher2Ref(ul, n, alpha, x, incX, y, incY, aDense, max(1, n))
+						want := zPackTri(ul, n, aDense)
+
+						xCopy := zSliceCopy(x)
+						yCopy := zSliceCopy(y)
+						impl.Zhpr2(ul, n, alpha, x, incX, y, incY, ap)
+
+						prefix := fmt.Sprintf("ul=%v,n=%v,incX=%v,incY=%v,alpha=%v", ul, n, incX, incY, alpha)
+						if !zSliceEqual(x, xCopy) {
+							t.Errorf("%v: unexpected modification of x", prefix)
+						}
+						if !zSliceEqual(y, yCopy) {
+							t.Errorf("%v: unexpected modification of y", prefix)
+						}
+						if !zSliceTolEqual(ap, want) {
+							t.Errorf("%v: unexpected A\nwant %v\ngot  %v", prefix, want, ap)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zsymmer interface {
+	Zsymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZsymmTest(t *testing.T, impl Zsymmer) {
+	zsymmTest(t, impl.Zsymm, false)
+}
+
+// zsymmTest tests symm, which computes
+//  C = alpha * A * B + beta * C  if s == blas.Left
+//  C = alpha * B * A + beta * C  if s == blas.Right
+// where A is a complex symmetric matrix, or a Hermitian matrix if herm is true.
+func zsymmTest(t *testing.T, symm func(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int), herm bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, test := range []struct {
+				m, n int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+			} {
+				m, n := test.m, test.n
+				na := m
+				if s == blas.Right {
+					na = n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, na+extra)
+					ldb := max(1, n+extra+1)
+					ldc := max(1, n+extra)
+					// The opposite triangle of A holds random values that
+					// may not be referenced and, for a Hermitian A, the
+					// diagonal has non-zero imaginary parts that must be
+					// ignored.
+					a := makeZGeneral(randomZSlice(na*na, rnd), na, na, lda)
+					var aDense []complex128
+					if herm {
+						aDense = zHermDense(ul, na, a, lda)
+					} else {
+						aDense = zSymDense(ul, na, a, lda)
+					}
+					b := makeZGeneral(randomZSlice(m*n, rnd), m, n, ldb)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []complex128{0, 1, -0.5 + 0.7i} {
+							cd := randomZSlice(m*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(m * n)
+							}
+							c := makeZGeneral(cd, m, n, ldc)
+							want := zSliceCopy(c)
+							if !(alpha == 0 && beta == 1) {
+								if s == blas.Left {
+									zmm(blas.NoTrans, blas.NoTrans, m, n, m, alpha, aDense, max(1, na), b, ldb, beta, want, ldc)
+								} else {
+									zmm(blas.NoTrans, blas.NoTrans, m, n, n, alpha, b, ldb, aDense, max(1, na), beta, want, ldc)
+								}
+							}
+
+							aCopy := zSliceCopy(a)
+							bCopy := zSliceCopy(b)
+							symm(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("s=%v,ul=%v,m=%v,n=%v,extra=%v,alpha=%v,beta=%v", s, ul, m, n, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
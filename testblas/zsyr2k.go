@@ -0,0 +1,83 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zsyr2ker interface {
+	Zsyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func Zsyr2kTest(t *testing.T, impl Zsyr2ker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				n, k int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {3, 2}, {4, 7}, {7, 3}, {10, 10},
+			} {
+				n, k := test.n, test.k
+				row, col := n, k
+				if tA == blas.Trans {
+					row, col = k, n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, col+extra)
+					ldb := max(1, col+extra+1)
+					ldc := max(1, n+extra)
+					a := makeZGeneral(randomZSlice(row*col, rnd), row, col, lda)
+					b := makeZGeneral(randomZSlice(row*col, rnd), row, col, ldb)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []complex128{0, 1, -0.5 + 0.7i} {
+							cd := randomZSlice(n*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(n * n)
+							}
+							c := makeZGeneral(cd, n, n, ldc)
+							want := zSliceCopy(c)
+							zsyr2kRef(ul, tA, n, k, alpha, a, lda, b, ldb, beta, want, ldc)
+
+							aCopy := zSliceCopy(a)
+							bCopy := zSliceCopy(b)
+							impl.Zsyr2k(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", ul, tA, n, k, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceEqual(b, bCopy) {
+								t.Errorf("%v: unexpected modification of B", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zsyr2kRef performs the symmetric rank-2k update
+//  C = alpha * A * B^T + alpha * B * A^T + beta * C  if tA == blas.NoTrans
+//  C = alpha * A^T * B + alpha * B^T * A + beta * C  if tA == blas.Trans
+// on the ul triangle of the n×n matrix C using a naive algorithm.
+func zsyr2kRef(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	tL, tR := blas.NoTrans, blas.Trans
+	if tA == blas.Trans {
+		tL, tR = blas.Trans, blas.NoTrans
+	}
+	p := make([]complex128, n*n)
+	zmm(tL, tR, n, n, k, alpha, a, lda, b, ldb, 0, p, n)
+	zmm(tL, tR, n, n, k, alpha, b, ldb, a, lda, 1, p, n)
+	zsymUpdateRef(ul, n, p, beta, c, ldc)
+}
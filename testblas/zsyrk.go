@@ -0,0 +1,94 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Zsyrker interface {
+	Zsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int)
+}
+
+func ZsyrkTest(t *testing.T, impl Zsyrker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				n, k int
+			}{
+				{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {3, 2}, {4, 7}, {7, 3}, {10, 10},
+			} {
+				n, k := test.n, test.k
+				rowA, colA := n, k
+				if tA == blas.Trans {
+					rowA, colA = k, n
+				}
+				for _, extra := range []int{0, 3} {
+					lda := max(1, colA+extra)
+					ldc := max(1, n+extra)
+					a := makeZGeneral(randomZSlice(rowA*colA, rnd), rowA, colA, lda)
+					for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+						for _, beta := range []complex128{0, 1, -0.5 + 0.7i} {
+							cd := randomZSlice(n*n, rnd)
+							if beta == 0 {
+								cd = zNaNSlice(n * n)
+							}
+							c := makeZGeneral(cd, n, n, ldc)
+							want := zSliceCopy(c)
+							zsyrkRef(ul, tA, n, k, alpha, a, lda, beta, want, ldc)
+
+							aCopy := zSliceCopy(a)
+							impl.Zsyrk(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", ul, tA, n, k, extra, alpha, beta)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceTolEqual(c, want) {
+								t.Errorf("%v: unexpected C\nwant %v\ngot  %v", prefix, want, c)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// zsyrkRef performs the symmetric rank-k update
+//  C = alpha * A * A^T + beta * C  if tA == blas.NoTrans
+//  C = alpha * A^T * A + beta * C  if tA == blas.Trans
+// on the ul triangle of the n×n matrix C using a naive algorithm.
+func zsyrkRef(ul blas.Uplo, tA blas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+	p := make([]complex128, n*n)
+	if tA == blas.NoTrans {
+		zmm(blas.NoTrans, blas.Trans, n, n, k, alpha, a, lda, a, lda, 0, p, n)
+	} else {
+		zmm(blas.Trans, blas.NoTrans, n, n, k, alpha, a, lda, a, lda, 0, p, n)
+	}
+	zsymUpdateRef(ul, n, p, beta, c, ldc)
+}
+
+// zsymUpdateRef sets the ul triangle of C to p + beta*C. C is not read when
+// beta is zero.
+func zsymUpdateRef(ul blas.Uplo, n int, p []complex128, beta complex128, c []complex128, ldc int) {
+	for i := 0; i < n; i++ {
+		jStart, jEnd := 0, i+1
+		if ul == blas.Upper {
+			jStart, jEnd = i, n
+		}
+		for j := jStart; j < jEnd; j++ {
+			v := p[i*n+j]
+			if beta != 0 {
+				v += beta * c[i*ldc+j]
+			}
+			c[i*ldc+j] = v
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztbsver interface {
+	Ztbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtbsvTest(t *testing.T, impl Ztbsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, test := range []struct {
+					n, k int
+				}{
+					{0, 0}, {0, 2}, {1, 0}, {1, 3}, {3, 1}, {4, 0}, {4, 3}, {5, 2}, {7, 3}, {10, 8},
+				} {
+					n, k := test.n, test.k
+					// Generate a dense matrix that is zero outside the band.
+					aDense := randomZTriangular(n, rnd)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if j < i-k || j > i+k {
+								aDense[i*n+j] = 0
+							}
+						}
+					}
+					tri, upper := zTriDense(ul, tA, d, n, aDense, max(1, n))
+					kL, kU := 0, k
+					if ul == blas.Lower {
+						kL, kU = k, 0
+					}
+					for _, extra := range []int{0, 3} {
+						lda := k + 1 + extra
+						a := zPackBand(kL, kU, lda, n, n, aDense)
+						for _, incX := range []int{-3, 1, 2} {
+							x := makeZVector(randomZSlice(n, rnd), incX)
+							want := zSliceCopy(x)
+							ztrsvRef(upper, n, tri, want, incX)
+
+							aCopy := zSliceCopy(a)
+							impl.Ztbsv(ul, tA, d, n, k, a, lda, x, incX)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,d=%v,n=%v,k=%v,lda=%v,incX=%v", ul, tA, d, n, k, lda, incX)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceTolEqual(x, want) {
+								t.Errorf("%v: unexpected x\nwant %v\ngot  %v", prefix, want, x)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztpmver interface {
+	Ztpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int)
+}
+
+func ZtpmvTest(t *testing.T, impl Ztpmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+					aDense := randomZSlice(n*n, rnd)
+					tri, _ := zTriDense(ul, tA, d, n, aDense, max(1, n))
+					ap := zPackTri(ul, n, aDense)
+					for _, incX := range []int{-3, 1, 2} {
+						x := makeZVector(randomZSlice(n, rnd), incX)
+						want := zSliceCopy(x)
+						ztrmvRef(n, tri, want, incX)
+
+						apCopy := zSliceCopy(ap)
+						impl.Ztpmv(ul, tA, d, n, ap, x, incX)
+
+						prefix := fmt.Sprintf("ul=%v,tA=%v,d=%v,n=%v,incX=%v", ul, tA, d, n, incX)
+						if !zSliceEqual(ap, apCopy) {
+							t.Errorf("%v: unexpected modification of A", prefix)
+						}
+						if !zSliceTolEqual(x, want) {
+							t.Errorf("%v: unexpected x\nwant %v\ngot  %v", prefix, want, x)
+						}
+					}
+				}
+			}
+		}
+	}
+}
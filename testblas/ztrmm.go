@@ -0,0 +1,81 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztrmmer interface {
+	Ztrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int)
+}
+
+func ZtrmmTest(t *testing.T, impl Ztrmmer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, test := range []struct {
+						m, n int
+					}{
+						{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+					} {
+						m, n := test.m, test.n
+						na := m
+						if s == blas.Right {
+							na = n
+						}
+						for _, extra := range []int{0, 3} {
+							lda := max(1, na+extra)
+							ldb := max(1, n+extra+1)
+							// The opposite triangle of A holds random values
+							// and, for a unit diagonal, so does the diagonal.
+							// Neither may be referenced by Ztrmm.
+							a := makeZGeneral(randomZSlice(na*na, rnd), na, na, lda)
+							for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+								b := makeZGeneral(randomZSlice(m*n, rnd), m, n, ldb)
+								want := zSliceCopy(b)
+								ztrmmRef(s, ul, tA, d, m, n, alpha, a, lda, want, ldb)
+
+								aCopy := zSliceCopy(a)
+								impl.Ztrmm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+
+								prefix := fmt.Sprintf("s=%v,ul=%v,tA=%v,d=%v,m=%v,n=%v,extra=%v,alpha=%v", s, ul, tA, d, m, n, extra, alpha)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceTolEqual(b, want) {
+									t.Errorf("%v: unexpected B\nwant %v\ngot  %v", prefix, want, b)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// ztrmmRef computes
+//  B = alpha * op(A) * B  if s == blas.Left
+//  B = alpha * B * op(A)  if s == blas.Right
+// using a naive algorithm.
+func ztrmmRef(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	p := make([]complex128, m*n)
+	if s == blas.Left {
+		tri, _ := zTriDense(ul, tA, d, m, a, lda)
+		zmm(blas.NoTrans, blas.NoTrans, m, n, m, alpha, tri, m, b, ldb, 0, p, n)
+	} else {
+		tri, _ := zTriDense(ul, tA, d, n, a, lda)
+		zmm(blas.NoTrans, blas.NoTrans, m, n, n, alpha, b, ldb, tri, n, 0, p, n)
+	}
+	for i := 0; i < m; i++ {
+		copy(b[i*ldb:i*ldb+n], p[i*n:i*n+n])
+	}
+}
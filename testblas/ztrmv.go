@@ -0,0 +1,55 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztrmver interface {
+	Ztrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtrmvTest(t *testing.T, impl Ztrmver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+					for _, extra := range []int{0, 3} {
+						lda := max(1, n+extra)
+						// The opposite triangle of A holds random values
+						// and, for a unit diagonal, so does the diagonal.
+						// Neither may be referenced by Ztrmv.
+						a := makeZGeneral(randomZSlice(n*n, rnd), n, n, lda)
+						tri, _ := zTriDense(ul, tA, d, n, a, lda)
+						for _, incX := range []int{-3, 1, 2} {
+							x := makeZVector(randomZSlice(n, rnd), incX)
+							want := zSliceCopy(x)
+							ztrmvRef(n, tri, want, incX)
+
+							aCopy := zSliceCopy(a)
+							impl.Ztrmv(ul, tA, d, n, a, lda, x, incX)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,d=%v,n=%v,lda=%v,incX=%v", ul, tA, d, n, lda, incX)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceTolEqual(x, want) {
+								t.Errorf("%v: unexpected x\nwant %v\ngot  %v", prefix, want, x)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// ztrmvRef computes x = t * x in place, where t is an n×n dense triangular
+// matrix with stride n.
+func ztrmvRef(n int, t []complex128, x []complex128, incX int) {
+	zmv(blas.NoTrans, n, n, 1, t, max(1, n), zSliceCopy(x), incX, 0, x, incX)
+}
@@ -0,0 +1,93 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztrsmer interface {
+	Ztrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int)
+}
+
+func ZtrsmTest(t *testing.T, impl Ztrsmer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, test := range []struct {
+						m, n int
+					}{
+						{0, 0}, {0, 3}, {3, 0}, {1, 1}, {1, 4}, {4, 1}, {3, 4}, {5, 2}, {7, 7}, {10, 13},
+					} {
+						m, n := test.m, test.n
+						na := m
+						if s == blas.Right {
+							na = n
+						}
+						for _, extra := range []int{0, 3} {
+							lda := max(1, na+extra)
+							ldb := max(1, n+extra+1)
+							// The opposite triangle of A holds random values
+							// and, for a unit diagonal, so does the diagonal.
+							// Neither may be referenced by Ztrsm.
+							a := makeZGeneral(randomZTriangular(na, rnd), na, na, lda)
+							for _, alpha := range []complex128{0, 1, 0.3 - 1.2i} {
+								b := makeZGeneral(randomZSlice(m*n, rnd), m, n, ldb)
+								want := zSliceCopy(b)
+								ztrsmRef(s, ul, tA, d, m, n, alpha, a, lda, want, ldb)
+
+								aCopy := zSliceCopy(a)
+								impl.Ztrsm(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+
+								prefix := fmt.Sprintf("s=%v,ul=%v,tA=%v,d=%v,m=%v,n=%v,extra=%v,alpha=%v", s, ul, tA, d, m, n, extra, alpha)
+								if !zSliceEqual(a, aCopy) {
+									t.Errorf("%v: unexpected modification of A", prefix)
+								}
+								if !zSliceTolEqual(b, want) {
+									t.Errorf("%v: unexpected B\nwant %v\ngot  %v", prefix, want, b)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// ztrsmRef solves
+//  op(A) * X = alpha * B  if s == blas.Left
+//  X * op(A) = alpha * B  if s == blas.Right
+// for X, storing the result in B, by substitution.
+func ztrsmRef(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	if m == 0 || n == 0 {
+		return
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			b[i*ldb+j] *= alpha
+		}
+	}
+	if s == blas.Left {
+		tri, upper := zTriDense(ul, tA, d, m, a, lda)
+		for j := 0; j < n; j++ {
+			ztrsvRef(upper, m, tri, b[j:], ldb)
+		}
+		return
+	}
+	// X * op(A) = B is equivalent to op(A)^T * X^T = B^T.
+	tri, upper := zTriDense(ul, tA, d, n, a, lda)
+	triT := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			triT[i*n+j] = tri[j*n+i]
+		}
+	}
+	for i := 0; i < m; i++ {
+		ztrsvRef(!upper, n, triT, b[i*ldb:], 1)
+	}
+}
@@ -0,0 +1,49 @@
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Ztrsver interface {
+	Ztrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int)
+}
+
+func ZtrsvTest(t *testing.T, impl Ztrsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+			for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 3, 4, 7, 10} {
+					for _, extra := range []int{0, 3} {
+						lda := max(1, n+extra)
+						// The opposite triangle of A holds random values
+						// and, for a unit diagonal, so does the diagonal.
+						// Neither may be referenced by Ztrsv.
+						a := makeZGeneral(randomZTriangular(n, rnd), n, n, lda)
+						tri, upper := zTriDense(ul, tA, d, n, a, lda)
+						for _, incX := range []int{-3, 1, 2} {
+							x := makeZVector(randomZSlice(n, rnd), incX)
+							want := zSliceCopy(x)
+							ztrsvRef(upper, n, tri, want, incX)
+
+							aCopy := zSliceCopy(a)
+							impl.Ztrsv(ul, tA, d, n, a, lda, x, incX)
+
+							prefix := fmt.Sprintf("ul=%v,tA=%v,d=%v,n=%v,lda=%v,incX=%v", ul, tA, d, n, lda, incX)
+							if !zSliceEqual(a, aCopy) {
+								t.Errorf("%v: unexpected modification of A", prefix)
+							}
+							if !zSliceTolEqual(x, want) {
+								t.Errorf("%v: unexpected x\nwant %v\ngot  %v", prefix, want, x)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}